@@ -0,0 +1,91 @@
+package bitboard
+
+import "chess/board"
+
+var (
+	knightAttacks [64]Bitboard
+	kingAttacks   [64]Bitboard
+	pawnAttacks   [3][64]Bitboard
+)
+
+var knightOffsets = [8][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+var kingOffsets = [8][2]int{{0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+var rookDirections = [4][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+var bishopDirections = [4][2]int{{1, 1}, {1, -1}, {-1, -1}, {-1, 1}}
+
+func init() {
+	for square := Square(0); square < 64; square++ {
+		knightAttacks[square] = offsetAttacks(square, knightOffsets[:])
+		kingAttacks[square] = offsetAttacks(square, kingOffsets[:])
+		pawnAttacks[board.White][square] = offsetAttacks(square, [][2]int{{-1, 1}, {1, 1}})
+		pawnAttacks[board.Black][square] = offsetAttacks(square, [][2]int{{-1, -1}, {1, -1}})
+	}
+}
+
+// KnightAttacks returns squares attacked by a knight standing on given square
+func KnightAttacks(square Square) Bitboard {
+	return knightAttacks[square]
+}
+
+// KingAttacks returns squares attacked by a king standing on given square
+func KingAttacks(square Square) Bitboard {
+	return kingAttacks[square]
+}
+
+// PawnAttacks returns squares attacked by a pawn of given side standing on given square
+func PawnAttacks(side board.FigureSide, square Square) Bitboard {
+	return pawnAttacks[side][square]
+}
+
+// RookAttacks returns squares attacked by a rook standing on given square. A ray ends at the first occupied square
+func RookAttacks(square Square, occupied Bitboard) Bitboard {
+	magic := &rookMagics[square]
+	return magic.attacks[magic.index(occupied)]
+}
+
+// BishopAttacks returns squares attacked by a bishop standing on given square
+func BishopAttacks(square Square, occupied Bitboard) Bitboard {
+	magic := &bishopMagics[square]
+	return magic.attacks[magic.index(occupied)]
+}
+
+// QueenAttacks returns squares attacked by a queen standing on given square
+func QueenAttacks(square Square, occupied Bitboard) Bitboard {
+	return RookAttacks(square, occupied) | BishopAttacks(square, occupied)
+}
+
+func offsetAttacks(square Square, offsets [][2]int) Bitboard {
+	var attacks Bitboard
+	for _, offset := range offsets {
+		col, row := square.Col()+offset[0], square.Row()+offset[1]
+		if isOnBoard(col, row) {
+			attacks |= Of(squareAt(col, row))
+		}
+	}
+	return attacks
+}
+
+// slidingAttacks walks the rays square by square. It is used to fill the magic tables
+func slidingAttacks(square Square, occupied Bitboard, directions [4][2]int) Bitboard {
+	var attacks Bitboard
+	for _, direction := range directions {
+		col, row := square.Col()+direction[0], square.Row()+direction[1]
+		for isOnBoard(col, row) {
+			target := squareAt(col, row)
+			attacks |= Of(target)
+			if occupied.Has(target) {
+				break
+			}
+			col, row = col+direction[0], row+direction[1]
+		}
+	}
+	return attacks
+}
+
+func isOnBoard(col int, row int) bool {
+	return col >= 0 && col < board.ChessboardSize && row >= 0 && row < board.ChessboardSize
+}
+
+func squareAt(col int, row int) Square {
+	return Square(row*board.ChessboardSize + col)
+}
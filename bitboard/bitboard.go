@@ -0,0 +1,69 @@
+package bitboard
+
+import (
+	"chess/board"
+	"math/bits"
+)
+
+// Bitboard is a set of squares, bit i stands for Square i
+type Bitboard uint64
+
+// Square is index of a field, a1 is 0, h1 is 7 and h8 is 63
+type Square int
+
+const NoSquare Square = -1
+
+// SquareOf returns square of given cords
+func SquareOf(cords board.Cords) Square {
+	return Square(cords.Row*board.ChessboardSize + cords.Col)
+}
+
+// Cords returns cords of the square
+func (square Square) Cords() board.Cords {
+	return board.Cords{Col: square.Col(), Row: square.Row()}
+}
+
+func (square Square) Col() int {
+	return int(square) % board.ChessboardSize
+}
+
+func (square Square) Row() int {
+	return int(square) / board.ChessboardSize
+}
+
+func (square Square) String() string {
+	if square == NoSquare {
+		return "-"
+	}
+	return square.Cords().String()
+}
+
+// Of returns bitboard containing only given square
+func Of(square Square) Bitboard {
+	return Bitboard(1) << uint(square)
+}
+
+// Has checks whether the square belongs to the set
+func (bitboard Bitboard) Has(square Square) bool {
+	return bitboard&Of(square) != 0
+}
+
+// Count returns number of squares in the set
+func (bitboard Bitboard) Count() int {
+	return bits.OnesCount64(uint64(bitboard))
+}
+
+// First returns the lowest square of the set, or NoSquare if the set is empty
+func (bitboard Bitboard) First() Square {
+	if bitboard == 0 {
+		return NoSquare
+	}
+	return Square(bits.TrailingZeros64(uint64(bitboard)))
+}
+
+// PopFirst removes the lowest square from the set and returns it
+func (bitboard *Bitboard) PopFirst() Square {
+	square := bitboard.First()
+	*bitboard &= *bitboard - 1
+	return square
+}
@@ -0,0 +1,102 @@
+package bitboard
+
+// magic maps every occupancy of the relevant squares of a slider to its attacks:
+// attacks[(occupied & mask) * number >> shift]
+type magic struct {
+	mask    Bitboard
+	number  uint64
+	shift   uint
+	attacks []Bitboard
+}
+
+func (magic *magic) index(occupied Bitboard) uint64 {
+	return uint64(occupied&magic.mask) * magic.number >> magic.shift
+}
+
+var (
+	rookMagics   [64]magic
+	bishopMagics [64]magic
+)
+
+// magicSeed makes the search find the same magic numbers on every start
+const magicSeed = 0x9e3779b97f4a7c15
+
+func init() {
+	random := randomSource(magicSeed)
+	for square := Square(0); square < 64; square++ {
+		rookMagics[square] = findMagic(square, rookDirections, &random)
+		bishopMagics[square] = findMagic(square, bishopDirections, &random)
+	}
+}
+
+// findMagic tries sparse random numbers until one maps every occupancy of the relevant squares
+// without destructive collisions
+func findMagic(square Square, directions [4][2]int, random *randomSource) magic {
+	mask := relevantMask(square, directions)
+	bitCount := mask.Count()
+	occupancies := make([]Bitboard, 0, 1<<bitCount)
+	references := make([]Bitboard, 0, 1<<bitCount)
+	// Carry-Rippler enumeration of all subsets of the mask
+	for subset := Bitboard(0); ; {
+		occupancies = append(occupancies, subset)
+		references = append(references, slidingAttacks(square, subset, directions))
+		subset = (subset - mask) & mask
+		if subset == 0 {
+			break
+		}
+	}
+
+	candidate := magic{mask: mask, shift: uint(64 - bitCount), attacks: make([]Bitboard, 1<<bitCount)}
+	used := make([]bool, 1<<bitCount)
+	for {
+		candidate.number = random.sparse()
+		if Bitboard(uint64(mask)*candidate.number>>56).Count() < 6 {
+			continue
+		}
+		clear(used)
+		if fillAttacks(&candidate, used, occupancies, references) {
+			return candidate
+		}
+	}
+}
+
+func fillAttacks(candidate *magic, used []bool, occupancies []Bitboard, references []Bitboard) bool {
+	for i, occupied := range occupancies {
+		index := candidate.index(occupied)
+		if used[index] && candidate.attacks[index] != references[i] {
+			return false
+		}
+		used[index] = true
+		candidate.attacks[index] = references[i]
+	}
+	return true
+}
+
+// relevantMask returns squares whose occupancy changes attacks of a slider. The last square of every ray
+// is left out since the ray ends there anyway
+func relevantMask(square Square, directions [4][2]int) Bitboard {
+	var mask Bitboard
+	for _, direction := range directions {
+		col, row := square.Col()+direction[0], square.Row()+direction[1]
+		for isOnBoard(col+direction[0], row+direction[1]) {
+			mask |= Of(squareAt(col, row))
+			col, row = col+direction[0], row+direction[1]
+		}
+	}
+	return mask
+}
+
+// randomSource is a xorshift64* generator, so magic numbers don't depend on math/rand
+type randomSource uint64
+
+func (random *randomSource) next() uint64 {
+	*random ^= *random >> 12
+	*random ^= *random << 25
+	*random ^= *random >> 27
+	return uint64(*random) * 0x2545f4914f6cdd1d
+}
+
+// sparse returns random number with few bits set, such numbers make good magics more often
+func (random *randomSource) sparse() uint64 {
+	return random.next() & random.next() & random.next()
+}
@@ -0,0 +1,221 @@
+package bitboard
+
+import (
+	"chess/board"
+	"strings"
+)
+
+// Move is a move of the bitboard position. Promotion is EmptyType unless a pawn is promoted
+type Move struct {
+	From      Square
+	To        Square
+	Promotion board.FigureType
+}
+
+// String returns the move in coordinate notation, e.g. "e2e4" or "e7e8q"
+func (move Move) String() string {
+	return move.From.String() + move.To.String() + strings.ToLower(move.Promotion.Letter())
+}
+
+// BoardMove returns the move for the board the position was converted to
+func (move Move) BoardMove(chessBoard *board.Board) board.Move {
+	return board.MakeMove(chessBoard.GetField(move.From.Cords()), chessBoard.GetField(move.To.Cords()), move.Promotion)
+}
+
+// promotionTypes lists figures a pawn can be promoted to in the order moves are generated
+var promotionTypes = [4]board.FigureType{board.Queen, board.Rook, board.Bishop, board.Knight}
+
+// castlingRightsLost lists castling rights lost when a figure moves from or to the square
+var castlingRightsLost = map[Square]board.CastlingRights{
+	0:  {WhiteLong: true},
+	4:  {WhiteShort: true, WhiteLong: true},
+	7:  {WhiteShort: true},
+	56: {BlackLong: true},
+	60: {BlackShort: true, BlackLong: true},
+	63: {BlackShort: true},
+}
+
+// Move returns position after given move. The move must be pseudo-legal
+func (position Position) Move(move Move) Position {
+	side := position.SideToMove
+	opponent := side.Opposite()
+	figure, _ := position.FigureAt(move.From)
+	figureType := figure.FigureType
+
+	capturedSquare := move.To
+	if figureType == board.Pawn && move.To == position.EnPassant {
+		capturedSquare = squareAt(move.To.Col(), move.From.Row())
+	}
+	captured, isCapture := position.FigureAt(capturedSquare)
+	if isCapture {
+		position.remove(opponent, captured.FigureType, capturedSquare)
+	}
+
+	position.remove(side, figureType, move.From)
+	if move.Promotion != board.EmptyType {
+		position.put(side, move.Promotion, move.To)
+	} else {
+		position.put(side, figureType, move.To)
+	}
+
+	if figureType == board.King && abs(move.To.Col()-move.From.Col()) == 2 {
+		rookFrom, rookTo := squareAt(7, move.From.Row()), squareAt(5, move.From.Row())
+		if move.To.Col() == 2 {
+			rookFrom, rookTo = squareAt(0, move.From.Row()), squareAt(3, move.From.Row())
+		}
+		position.remove(side, board.Rook, rookFrom)
+		position.put(side, board.Rook, rookTo)
+	}
+
+	for _, square := range [2]Square{move.From, move.To} {
+		if lost, ok := castlingRightsLost[square]; ok {
+			position.Castling.WhiteShort = position.Castling.WhiteShort && !lost.WhiteShort
+			position.Castling.WhiteLong = position.Castling.WhiteLong && !lost.WhiteLong
+			position.Castling.BlackShort = position.Castling.BlackShort && !lost.BlackShort
+			position.Castling.BlackLong = position.Castling.BlackLong && !lost.BlackLong
+		}
+	}
+
+	position.EnPassant = NoSquare
+	if figureType == board.Pawn && abs(move.To.Row()-move.From.Row()) == 2 {
+		position.EnPassant = (move.From + move.To) / 2
+	}
+	if figureType == board.Pawn || isCapture {
+		position.HalfmoveClock = 0
+	} else {
+		position.HalfmoveClock++
+	}
+	if side == board.Black {
+		position.FullmoveNumber++
+	}
+	position.SideToMove = opponent
+	return position
+}
+
+// LegalMoves returns every legal move of the side to move
+func (position *Position) LegalMoves() []Move {
+	moves := position.PseudoLegalMoves(make([]Move, 0, 48))
+	legal := moves[:0]
+	side := position.SideToMove
+	for _, move := range moves {
+		next := position.Move(move)
+		if !next.IsInCheck(side) {
+			legal = append(legal, move)
+		}
+	}
+	return legal
+}
+
+// PseudoLegalMoves appends moves of the side to move to given slice, not checking whether the own king is left
+// in check. Castling is generated only when the king doesn't pass attacked squares
+func (position *Position) PseudoLegalMoves(moves []Move) []Move {
+	side := position.SideToMove
+	pieces := &position.Pieces[side]
+	targets := ^position.Occupied[side]
+
+	moves = position.pawnMoves(moves)
+	for knights := pieces[board.Knight]; knights != 0; {
+		from := knights.PopFirst()
+		moves = appendMoves(moves, from, KnightAttacks(from)&targets)
+	}
+	for bishops := pieces[board.Bishop]; bishops != 0; {
+		from := bishops.PopFirst()
+		moves = appendMoves(moves, from, BishopAttacks(from, position.All)&targets)
+	}
+	for rooks := pieces[board.Rook]; rooks != 0; {
+		from := rooks.PopFirst()
+		moves = appendMoves(moves, from, RookAttacks(from, position.All)&targets)
+	}
+	for queens := pieces[board.Queen]; queens != 0; {
+		from := queens.PopFirst()
+		moves = appendMoves(moves, from, QueenAttacks(from, position.All)&targets)
+	}
+	if king := position.King(side); king != NoSquare {
+		moves = appendMoves(moves, king, KingAttacks(king)&targets)
+		moves = position.castlingMoves(moves, king)
+	}
+	return moves
+}
+
+func (position *Position) pawnMoves(moves []Move) []Move {
+	side := position.SideToMove
+	forward, lastRow := 8, 7
+	if side == board.Black {
+		forward, lastRow = -8, 0
+	}
+	enemies := position.Occupied[side.Opposite()]
+	if position.EnPassant != NoSquare {
+		enemies |= Of(position.EnPassant)
+	}
+	for pawns := position.Pieces[side][board.Pawn]; pawns != 0; {
+		from := pawns.PopFirst()
+		destinations := PawnAttacks(side, from) & enemies
+		if oneStep := from + Square(forward); !position.All.Has(oneStep) {
+			destinations |= Of(oneStep)
+			twoSteps := oneStep + Square(forward)
+			if from.Row() == pawnInitialRow(side) && !position.All.Has(twoSteps) {
+				destinations |= Of(twoSteps)
+			}
+		}
+		for destinations != 0 {
+			to := destinations.PopFirst()
+			if to.Row() != lastRow {
+				moves = append(moves, Move{From: from, To: to})
+				continue
+			}
+			for _, promotion := range promotionTypes {
+				moves = append(moves, Move{From: from, To: to, Promotion: promotion})
+			}
+		}
+	}
+	return moves
+}
+
+func (position *Position) castlingMoves(moves []Move, king Square) []Move {
+	side := position.SideToMove
+	short, long := position.Castling.WhiteShort, position.Castling.WhiteLong
+	if side == board.Black {
+		short, long = position.Castling.BlackShort, position.Castling.BlackLong
+	}
+	if !short && !long || position.IsAttacked(king, side.Opposite()) {
+		return moves
+	}
+	row := king.Row()
+	if short && position.canCastle(squareAt(5, row), squareAt(6, row), squareAt(6, row)) {
+		moves = append(moves, Move{From: king, To: squareAt(6, row)})
+	}
+	if long && position.canCastle(squareAt(1, row), squareAt(3, row), squareAt(2, row)) {
+		moves = append(moves, Move{From: king, To: squareAt(2, row)})
+	}
+	return moves
+}
+
+// canCastle checks that squares from first to last are empty and the king doesn't pass attacked squares
+// on its way to the destination
+func (position *Position) canCastle(first Square, last Square, destination Square) bool {
+	opponent := position.SideToMove.Opposite()
+	for square := first; square <= last; square++ {
+		if position.All.Has(square) {
+			return false
+		}
+	}
+	passed := squareAt(5, destination.Row())
+	if destination.Col() == 2 {
+		passed = squareAt(3, destination.Row())
+	}
+	return !position.IsAttacked(passed, opponent) && !position.IsAttacked(destination, opponent)
+}
+
+func appendMoves(moves []Move, from Square, destinations Bitboard) []Move {
+	for destinations != 0 {
+		moves = append(moves, Move{From: from, To: destinations.PopFirst()})
+	}
+	return moves
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package bitboard
+
+// PerftResult is number of leaf positions reached after the move
+type PerftResult struct {
+	Move  Move
+	Nodes int
+}
+
+// Perft counts positions reachable from the position by exactly depth legal moves
+func (position *Position) Perft(depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := position.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+	nodes := 0
+	for _, move := range moves {
+		next := position.Move(move)
+		nodes += next.Perft(depth - 1)
+	}
+	return nodes
+}
+
+// Divide is Perft split by the first move
+func (position *Position) Divide(depth int) []PerftResult {
+	if depth == 0 {
+		return nil
+	}
+	moves := position.LegalMoves()
+	results := make([]PerftResult, 0, len(moves))
+	for _, move := range moves {
+		next := position.Move(move)
+		results = append(results, PerftResult{Move: move, Nodes: next.Perft(depth - 1)})
+	}
+	return results
+}
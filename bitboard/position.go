@@ -0,0 +1,130 @@
+package bitboard
+
+import "chess/board"
+
+// Position is a chess position stored as sets of squares per side and figure type.
+// It is a plain value, so copying it doesn't allocate
+type Position struct {
+	Pieces         [3][7]Bitboard
+	Occupied       [3]Bitboard
+	All            Bitboard
+	SideToMove     board.FigureSide
+	Castling       board.CastlingRights
+	EnPassant      Square
+	HalfmoveClock  int
+	FullmoveNumber int
+}
+
+// FromBoard converts given board to the bitboard position
+func FromBoard(chessBoard *board.Board) Position {
+	position := Position{
+		SideToMove:     chessBoard.SideToMove(),
+		Castling:       chessBoard.CastlingRights(),
+		EnPassant:      NoSquare,
+		HalfmoveClock:  chessBoard.HalfmoveClock(),
+		FullmoveNumber: chessBoard.FullmoveNumber(),
+	}
+	for square := Square(0); square < 64; square++ {
+		field := chessBoard.GetField(square.Cords())
+		if field.Filled {
+			position.put(field.Figure.FigureSide, field.Figure.FigureType, square)
+		}
+	}
+	if enPassantCords := chessBoard.EnPassantCords(); enPassantCords != nil {
+		position.EnPassant = SquareOf(*enPassantCords)
+	}
+	return position
+}
+
+// ToBoard converts the position to the board. Pawns on their initial rows, and kings and rooks keeping
+// castling rights are marked as unmoved, all other figures are marked as moved
+func (position *Position) ToBoard() board.Board {
+	chessBoard := board.MakeBoard()
+	for square := Square(0); square < 64; square++ {
+		figure, filled := position.FigureAt(square)
+		if !filled {
+			continue
+		}
+		figure.Moved = figure.FigureType != board.Pawn || square.Row() != pawnInitialRow(figure.FigureSide)
+		chessBoard.SetField(board.Field{Figure: figure, Cords: square.Cords(), Filled: true})
+	}
+	chessBoard.SetCastlingRights(position.Castling)
+	chessBoard.SetSideToMove(position.SideToMove)
+	chessBoard.SetHalfmoveClock(position.HalfmoveClock)
+	chessBoard.SetFullmoveNumber(position.FullmoveNumber)
+	if position.EnPassant != NoSquare {
+		enPassantCords := position.EnPassant.Cords()
+		chessBoard.SetEnPassantCords(&enPassantCords)
+	}
+	return chessBoard
+}
+
+// FigureAt returns figure standing on given square. Moved flag isn't tracked by the position and is always false
+func (position *Position) FigureAt(square Square) (board.Figure, bool) {
+	if !position.All.Has(square) {
+		return board.Figure{}, false
+	}
+	side := board.White
+	if position.Occupied[board.Black].Has(square) {
+		side = board.Black
+	}
+	for figureType := board.King; figureType <= board.Queen; figureType++ {
+		if position.Pieces[side][figureType].Has(square) {
+			return board.Figure{FigureType: figureType, FigureSide: side}, true
+		}
+	}
+	return board.Figure{}, false
+}
+
+// King returns square of the king of given side, or NoSquare if there is no king
+func (position *Position) King(side board.FigureSide) Square {
+	return position.Pieces[side][board.King].First()
+}
+
+// IsAttacked checks whether given square is attacked by any figure of given side
+func (position *Position) IsAttacked(square Square, attackerSide board.FigureSide) bool {
+	return position.AttackersOf(square, attackerSide, position.All) != 0
+}
+
+// IsFieldAttackedByOpposedSide checks whether field at given cords is attacked by any figure of opposed side,
+// the same way as Board.IsFieldAttackedByOpposedSide does
+func (position *Position) IsFieldAttackedByOpposedSide(cords board.Cords, side board.FigureSide) bool {
+	return position.IsAttacked(SquareOf(cords), side.Opposite())
+}
+
+// AttackersOf returns figures of given side attacking the square when only the occupied squares are filled
+func (position *Position) AttackersOf(square Square, attackerSide board.FigureSide, occupied Bitboard) Bitboard {
+	pieces := &position.Pieces[attackerSide]
+	return PawnAttacks(attackerSide.Opposite(), square)&pieces[board.Pawn] |
+		KnightAttacks(square)&pieces[board.Knight] |
+		KingAttacks(square)&pieces[board.King] |
+		RookAttacks(square, occupied)&(pieces[board.Rook]|pieces[board.Queen]) |
+		BishopAttacks(square, occupied)&(pieces[board.Bishop]|pieces[board.Queen])
+}
+
+// IsInCheck checks whether the king of given side is attacked
+func (position *Position) IsInCheck(side board.FigureSide) bool {
+	king := position.King(side)
+	return king != NoSquare && position.IsAttacked(king, side.Opposite())
+}
+
+func (position *Position) put(side board.FigureSide, figureType board.FigureType, square Square) {
+	bit := Of(square)
+	position.Pieces[side][figureType] |= bit
+	position.Occupied[side] |= bit
+	position.All |= bit
+}
+
+func (position *Position) remove(side board.FigureSide, figureType board.FigureType, square Square) {
+	bit := ^Of(square)
+	position.Pieces[side][figureType] &= bit
+	position.Occupied[side] &= bit
+	position.All &= bit
+}
+
+func pawnInitialRow(side board.FigureSide) int {
+	if side == board.White {
+		return 1
+	}
+	return 6
+}
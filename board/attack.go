@@ -0,0 +1,292 @@
+package board
+
+// fieldView gives read access to fields. It lets attack queries run against a board with a move applied
+// without copying the whole board
+type fieldView interface {
+	GetField(cords Cords) Field
+}
+
+// moveView shows the board as if the move was made
+type moveView struct {
+	board   *Board
+	changed [4]Field
+	count   int
+}
+
+func makeMoveView(board *Board, move MoveFields) moveView {
+	var view moveView
+	view.reset(board, move)
+	return view
+}
+
+// reset shows the board as if the move was made, so a view kept by the caller can be reused without allocating
+func (view *moveView) reset(board *Board, move MoveFields) {
+	view.board, view.count = board, 0
+	departure, destination := move.Departure(), move.Destination()
+	movingFigure := departure.Figure
+	movingFigure.Moved = true
+	if promoteToType := PromotionOf(move); promoteToType != EmptyType {
+		movingFigure.FigureType = promoteToType
+	}
+	view.set(Field{Cords: departure.Cords})
+	view.set(Field{Figure: movingFigure, Cords: destination.Cords, Filled: true})
+	if isCastling(departure, destination) {
+		rookDepartureCords, rookDestinationCords := castlingRookCords(departure, destination)
+		rook := board.GetField(rookDepartureCords).Figure
+		rook.Moved = true
+		view.set(Field{Cords: rookDepartureCords})
+		view.set(Field{Figure: rook, Cords: rookDestinationCords, Filled: true})
+	} else if isEnPassantMove(move) {
+		view.set(Field{Cords: enPassantCapturedCords(move)})
+	}
+}
+
+func (view *moveView) set(field Field) {
+	view.changed[view.count] = field
+	view.count++
+}
+
+func (view *moveView) GetField(cords Cords) Field {
+	for i := view.count - 1; i >= 0; i-- {
+		if view.changed[i].Cords == cords {
+			return view.changed[i]
+		}
+	}
+	return view.board.GetField(cords)
+}
+
+// kingCords returns cords of the king of given side after the move
+func (view *moveView) kingCords(side FigureSide) *Cords {
+	for i := view.count - 1; i >= 0; i-- {
+		figure := view.changed[i].Figure
+		if view.changed[i].Filled && figure.FigureType == King && figure.FigureSide == side {
+			return &view.changed[i].Cords
+		}
+	}
+	return view.board.findKing(side)
+}
+
+// Pin describes figure which can't leave the ray between its king and the pinning slider
+type Pin struct {
+	Pinned    Field
+	Pinner    Field
+	Direction Direction
+}
+
+// Attackers returns figures of the side opposed to given one attacking field at given cords
+func (board *Board) Attackers(cords Cords, side FigureSide) []Field {
+	var attackers []Field
+	visitAttackers(board, cords, side.Opposite(), func(attacker Field) bool {
+		attackers = append(attackers, attacker)
+		return true
+	})
+	return attackers
+}
+
+// Defenders returns figures of given side protecting field at given cords
+func (board *Board) Defenders(cords Cords, side FigureSide) []Field {
+	var defenders []Field
+	visitAttackers(board, cords, side, func(defender Field) bool {
+		defenders = append(defenders, defender)
+		return true
+	})
+	return defenders
+}
+
+// Checkers returns figures giving check to the king of given side
+func (board *Board) Checkers(side FigureSide) []Field {
+	kingCords := board.findKing(side)
+	if kingCords == nil {
+		return nil
+	}
+	return board.Attackers(*kingCords, side)
+}
+
+// IsInCheck checks whether the king of given side is attacked
+func (board *Board) IsInCheck(side FigureSide) bool {
+	kingCords := board.findKing(side)
+	return kingCords != nil && board.IsFieldAttackedByOpposedSide(*kingCords, side)
+}
+
+// Pins returns figures of given side absolutely pinned to their king
+func (board *Board) Pins(side FigureSide) []Pin {
+	kingCords := board.findKing(side)
+	if kingCords == nil {
+		return nil
+	}
+	var pins []Pin
+	for _, directions := range [][]Direction{LineDirections, DiagonalDirections} {
+		for _, direction := range directions {
+			if pin, pinned := board.pinAlong(*kingCords, direction, side); pinned {
+				pins = append(pins, pin)
+			}
+		}
+	}
+	return pins
+}
+
+// PinOf returns pin of the figure at given cords if the figure is absolutely pinned
+func (board *Board) PinOf(cords Cords) (Pin, bool) {
+	field := board.GetField(cords)
+	if !field.Filled {
+		return Pin{}, false
+	}
+	kingCords := board.findKing(field.Figure.FigureSide)
+	if kingCords == nil {
+		return Pin{}, false
+	}
+	direction, aligned := DirectionBetween(*kingCords, cords)
+	if !aligned {
+		return Pin{}, false
+	}
+	pin, pinned := board.pinAlong(*kingCords, direction, field.Figure.FigureSide)
+	if !pinned || pin.Pinned.Cords != cords {
+		return Pin{}, false
+	}
+	return pin, true
+}
+
+func (board *Board) pinAlong(kingCords Cords, direction Direction, side FigureSide) (Pin, bool) {
+	pinned, found := board.FirstBlocker(kingCords, direction)
+	if !found || pinned.Figure.FigureSide != side {
+		return Pin{}, false
+	}
+	pinner, found := board.FirstBlocker(pinned.Cords, direction)
+	if !found || pinner.Figure.FigureSide == side || !direction.isSlider(pinner.Figure.FigureType) {
+		return Pin{}, false
+	}
+	return Pin{Pinned: pinned, Pinner: pinner, Direction: direction}, true
+}
+
+// GivesCheck checks whether the opponent king is attacked after the move
+func (board *Board) GivesCheck(move MoveFields) bool {
+	side := move.Departure().Figure.FigureSide
+	view := makeMoveView(board, move)
+	kingCords := view.kingCords(side.Opposite())
+	return kingCords != nil && isAttacked(&view, *kingCords, side)
+}
+
+// GivesDiscoveredCheck checks whether the move opens a line for another figure to attack the opponent king
+func (board *Board) GivesDiscoveredCheck(move MoveFields) bool {
+	side := move.Departure().Figure.FigureSide
+	view := makeMoveView(board, move)
+	kingCords := view.kingCords(side.Opposite())
+	if kingCords == nil {
+		return false
+	}
+	movedCords := []Cords{move.Destination().Cords}
+	if isCastling(move.Departure(), move.Destination()) {
+		_, rookDestinationCords := castlingRookCords(move.Departure(), move.Destination())
+		movedCords = append(movedCords, rookDestinationCords)
+	}
+	discovered := false
+	visitAttackers(&view, *kingCords, side, func(attacker Field) bool {
+		for _, cords := range movedCords {
+			if attacker.Cords == cords {
+				return true
+			}
+		}
+		discovered = true
+		return false
+	})
+	return discovered
+}
+
+// isKingAttackedAfterMove checks whether the move leaves the king of the moving side attacked
+func (board *Board) isKingAttackedAfterMove(move MoveFields) bool {
+	side := move.Departure().Figure.FigureSide
+	kingCords := board.findKing(side)
+	if kingCords == nil {
+		return false
+	}
+	if move.Departure().Figure.FigureType == King || isEnPassantMove(move) {
+		var view moveView
+		return view.isKingAttackedAfterMove(board, move)
+	}
+
+	checkers := board.Checkers(side)
+	if len(checkers) > 1 {
+		return true
+	}
+	destinationCords := move.Destination().Cords
+	if pin, pinned := board.PinOf(move.Departure().Cords); pinned {
+		direction, aligned := DirectionBetween(*kingCords, destinationCords)
+		if !aligned || direction != pin.Direction {
+			return true
+		}
+	}
+	if len(checkers) == 1 {
+		checkerCords := checkers[0].Cords
+		if destinationCords == checkerCords {
+			return false
+		}
+		for _, cords := range Between(*kingCords, checkerCords) {
+			if cords == destinationCords {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isKingAttackedAfterMove checks whether the king of the moving side is attacked after the move, reusing the view
+func (view *moveView) isKingAttackedAfterMove(board *Board, move MoveFields) bool {
+	side := move.Departure().Figure.FigureSide
+	view.reset(board, move)
+	return isAttacked(view, *view.kingCords(side), side.Opposite())
+}
+
+func isAttacked(view fieldView, cords Cords, attackerSide FigureSide) bool {
+	attacked := false
+	visitAttackers(view, cords, attackerSide, func(Field) bool {
+		attacked = true
+		return false
+	})
+	return attacked
+}
+
+// visitAttackers calls visit for every figure of attacker side attacking given cords until visit returns false
+func visitAttackers(view fieldView, cords Cords, attackerSide FigureSide, visit func(attacker Field) bool) {
+	if attackerSide == EmptySide {
+		return
+	}
+	isAttacker := func(candidate Cords, figureType FigureType) bool {
+		if !isOnBoard(candidate) {
+			return false
+		}
+		field := view.GetField(candidate)
+		return field.Filled && field.Figure.FigureSide == attackerSide && field.Figure.FigureType == figureType
+	}
+
+	pawnRow := cords.Row - 1
+	if attackerSide == Black {
+		pawnRow = cords.Row + 1
+	}
+	for _, col := range []int{cords.Col - 1, cords.Col + 1} {
+		if candidate := (Cords{Col: col, Row: pawnRow}); isAttacker(candidate, Pawn) && !visit(view.GetField(candidate)) {
+			return
+		}
+	}
+	for _, offset := range knightOffsets {
+		candidate := Cords{Col: cords.Col + offset.Col, Row: cords.Row + offset.Row}
+		if isAttacker(candidate, Knight) && !visit(view.GetField(candidate)) {
+			return
+		}
+	}
+	for _, directions := range [][]Direction{LineDirections, DiagonalDirections} {
+		for _, direction := range directions {
+			blocker, found := firstBlocker(view, cords, direction)
+			if found && blocker.Figure.FigureSide == attackerSide &&
+				direction.isSlider(blocker.Figure.FigureType) && !visit(blocker) {
+				return
+			}
+		}
+	}
+	for _, offset := range kingOffsets {
+		candidate := Cords{Col: cords.Col + offset.Col, Row: cords.Row + offset.Row}
+		if isAttacker(candidate, King) && !visit(view.GetField(candidate)) {
+			return
+		}
+	}
+}
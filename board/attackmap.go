@@ -0,0 +1,151 @@
+package board
+
+import "sync"
+
+// SquareAttacks describes how one side attacks a field
+type SquareAttacks struct {
+	Attackers []Cords
+	ByType    [Queen + 1]int
+}
+
+// Count returns number of figures attacking the field
+func (attacks SquareAttacks) Count() int {
+	return len(attacks.Attackers)
+}
+
+// PieceAttacks lists fields attacked by a figure. Fields taken by figures of the same side are included,
+// since the figure protects them
+type PieceAttacks struct {
+	Field    Field
+	Attacked []Cords
+}
+
+// AttackMap shows every attack of both sides in a position
+type AttackMap struct {
+	squares [Black + 1][ChessboardSize][ChessboardSize]SquareAttacks
+	pieces  [Black + 1][]PieceAttacks
+}
+
+// Square returns attacks of given side on field at given cords
+func (attackMap *AttackMap) Square(cords Cords, side FigureSide) SquareAttacks {
+	return attackMap.squares[side][cords.Row][cords.Col]
+}
+
+// Pieces returns attacks of every figure of given side
+func (attackMap *AttackMap) Pieces(side FigureSide) []PieceAttacks {
+	return attackMap.pieces[side]
+}
+
+// AttackMap builds attacks of all figures. Pawns attack diagonally forward whether the fields are taken or not,
+// sliders attack up to and including the first filled field, the same way attacks are detected for move validation
+func (board *Board) AttackMap() AttackMap {
+	var attackMap AttackMap
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := board.board[row][col]
+			if !field.Filled {
+				continue
+			}
+			attacked := board.attackedBy(field)
+			side := field.Figure.FigureSide
+			for _, cords := range attacked {
+				square := &attackMap.squares[side][cords.Row][cords.Col]
+				square.Attackers = append(square.Attackers, field.Cords)
+				square.ByType[field.Figure.FigureType]++
+			}
+			attackMap.pieces[side] = append(attackMap.pieces[side], PieceAttacks{Field: field, Attacked: attacked})
+		}
+	}
+	return attackMap
+}
+
+// attackedBy returns fields attacked by the figure standing on given field
+func (board *Board) attackedBy(field Field) []Cords {
+	attacked := make([]Cords, 0, 8)
+	board.VisitAttacked(field, func(cords Cords) {
+		attacked = append(attacked, cords)
+	})
+	return attacked
+}
+
+// VisitAttacked calls visit for every field attacked by the figure standing on given field, by the same rules
+// as AttackMap, without allocating
+func (board *Board) VisitAttacked(field Field, visit func(cords Cords)) {
+	visitOffsets := func(offsets []Cords) {
+		for _, offset := range offsets {
+			if cords := (Cords{Col: field.Cords.Col + offset.Col, Row: field.Cords.Row + offset.Row}); isOnBoard(cords) {
+				visit(cords)
+			}
+		}
+	}
+	visitRays := func(directions []Direction) {
+		for _, direction := range directions {
+			for cords := direction.Next(field.Cords); isOnBoard(cords); cords = direction.Next(cords) {
+				visit(cords)
+				if board.GetField(cords).Filled {
+					break
+				}
+			}
+		}
+	}
+
+	switch field.Figure.FigureType {
+	case Pawn:
+		visitOffsets(pawnAttackOffsets[field.Figure.FigureSide][:])
+	case Knight:
+		visitOffsets(knightOffsets)
+	case King:
+		visitOffsets(kingOffsets)
+	case Rook:
+		visitRays(LineDirections)
+	case Bishop:
+		visitRays(DiagonalDirections)
+	case Queen:
+		visitRays(LineDirections)
+		visitRays(DiagonalDirections)
+	}
+}
+
+var pawnAttackOffsets = [Black + 1][2]Cords{
+	White: {{Col: -1, Row: 1}, {Col: 1, Row: 1}},
+	Black: {{Col: -1, Row: -1}, {Col: 1, Row: -1}},
+}
+
+// stagedGenerators keeps the generators used by Mobility, so their move buffers are reused
+var stagedGenerators = sync.Pool{New: func() any { return MakeStagedMoveGenerator() }}
+
+// PieceMobility is number of legal moves of a figure. Every promotion choice counts as a separate move
+type PieceMobility struct {
+	Field Field
+	Moves int
+}
+
+// Mobility returns number of legal moves of every figure of given side, as if the side was to move.
+// When the side isn't to move, en passant captures are not counted
+func (board *Board) Mobility(side FigureSide) []PieceMobility {
+	position := board
+	if side != board.sideToMove {
+		flipped := board.Copy()
+		flipped.SetSideToMove(side)
+		flipped.SetEnPassantCords(nil)
+		position = &flipped
+	}
+	generator := stagedGenerators.Get().(*StagedMoveGenerator)
+	defer stagedGenerators.Put(generator)
+	var movesByField [ChessboardSize][ChessboardSize]int
+	for _, move := range generator.LegalMoves(position) {
+		departure := move.Departure().Cords
+		movesByField[departure.Row][departure.Col]++
+	}
+
+	mobility := make([]PieceMobility, 0, 2*ChessboardSize)
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := board.board[row][col]
+			if field.Filled && field.Figure.FigureSide == side {
+				mobility = append(mobility, PieceMobility{Field: field, Moves: movesByField[row][col]})
+			}
+		}
+	}
+	return mobility
+}
@@ -1,20 +1,28 @@
 package board
 
-import (
-	mapset "github.com/deckarep/golang-set/v2"
-	"math"
-)
-
 const ChessboardSize = 8
 
-var lineFiguresToSearch = mapset.NewSet(Queen, Rook)
-var diagonalFiguresToSearch = mapset.NewSet(Queen, Bishop)
+// boardCords holds cords of every field, so king cords can be referenced without allocating them on each move
+var boardCords = func() (cords [ChessboardSize][ChessboardSize]Cords) {
+	for row := range cords {
+		for col := range cords[row] {
+			cords[row][col] = Cords{Col: col, Row: row}
+		}
+	}
+	return cords
+}()
 
 type Board struct {
 	board          [][]Field
 	whiteKingCords *Cords
 	blackKingCords *Cords
-	lastMove       *Move
+	lastMove       Move
+	sideToMove     FigureSide
+	halfmoveClock  int
+	fullmoveNumber int
+	undoStack      []undoState
+	figuresHash    uint64
+	stateHash      uint64
 }
 
 func (board *Board) GetKingCords(kingSide FigureSide) *Cords {
@@ -25,7 +33,7 @@ func (board *Board) GetKingCords(kingSide FigureSide) *Cords {
 	}
 }
 
-// Copy returns deep Copy of given Board
+// Copy returns deep Copy of given Board. The last move is kept, so the copy allows the same en passant capture
 func (board *Board) Copy() Board {
 	duplicate := make([][]Field, ChessboardSize)
 	for i := range board.board {
@@ -36,6 +44,12 @@ func (board *Board) Copy() Board {
 		board:          duplicate,
 		whiteKingCords: board.whiteKingCords,
 		blackKingCords: board.blackKingCords,
+		lastMove:       board.lastMove,
+		sideToMove:     board.sideToMove,
+		halfmoveClock:  board.halfmoveClock,
+		fullmoveNumber: board.fullmoveNumber,
+		figuresHash:    board.figuresHash,
+		stateHash:      board.stateHash,
 	}
 }
 
@@ -46,152 +60,115 @@ func (board *Board) GetField(cords Cords) Field {
 
 // SetField puts given Field to given Cords
 func (board *Board) SetField(field Field) {
+	board.figuresHash ^= fieldKey(board.board[field.Cords.Row][field.Cords.Col]) ^ fieldKey(field)
+	stateHash := board.fieldStateHash(field.Cords)
 	board.board[field.Cords.Row][field.Cords.Col] = field
+	board.stateHash ^= stateHash ^ board.fieldStateHash(field.Cords)
 	if field.Figure.FigureType == King {
+		kingCords := &boardCords[field.Cords.Row][field.Cords.Col]
 		if field.Figure.FigureSide == White {
-			board.whiteKingCords = &field.Cords
+			board.whiteKingCords = kingCords
 		} else {
-			board.blackKingCords = &field.Cords
+			board.blackKingCords = kingCords
 		}
 	}
 }
 
+// Move returns new board with given move applied. The board itself isn't changed
 func (board *Board) Move(move Move) Board {
-	movingFigure := move.Departure().Figure
-	destinationCords := move.Destination().Cords
-	departureCords := move.Departure().Cords
-
-	movingFigure.Moved = true
-
-	newDeparture := Field{Cords: departureCords, Filled: false}
-	newDestination := Field{Figure: movingFigure, Cords: destinationCords, Filled: true}
 	actualBoard := board.Copy()
+	actualBoard.MakeMove(move)
+	actualBoard.undoStack = nil
+	return actualBoard
+}
 
-	if castleMove, isCastleMove := move.(CastleMove); isCastleMove {
-		rook := board.GetField(castleMove.RookDepartureCords()).Figure
-		rook.Moved = true
-		newRookDeparture := Field{Cords: castleMove.RookDepartureCords(), Filled: false}
-		newRookDestination := Field{Figure: rook, Cords: castleMove.RookDestinationCords(), Filled: true}
-		actualBoard.SetField(newRookDeparture)
-		actualBoard.SetField(newRookDestination)
-	} else if promotionMove, isPromotionMove := move.(PromotionMove); isPromotionMove {
-		newDestination.Figure.FigureType = promotionMove.PromoteToType()
-	}
+// IsFieldAttackedByOpposedSide checks whether field at given cords is attacked by any figure of opposed side
+func (board *Board) IsFieldAttackedByOpposedSide(cords Cords, side FigureSide) bool {
+	return isAttacked(board, cords, side.Opposite())
+}
 
-	actualBoard.SetField(newDeparture)
-	actualBoard.SetField(newDestination)
+// SideToMove returns side which has to make the next move
+func (board *Board) SideToMove() FigureSide {
+	return board.sideToMove
+}
 
-	if movingFigure.FigureType == King {
-		if movingFigure.FigureSide == White {
-			actualBoard.whiteKingCords = &destinationCords
-		} else {
-			actualBoard.blackKingCords = &destinationCords
-		}
+// SetSideToMove sets side which has to make the next move
+func (board *Board) SetSideToMove(side FigureSide) {
+	board.stateHash ^= board.enPassantHash()
+	if side != board.sideToMove {
+		board.stateHash ^= blackToMove
 	}
+	board.sideToMove = side
+	board.stateHash ^= board.enPassantHash()
+}
 
-	actualBoard.lastMove = &move
-
-	return actualBoard
+// HalfmoveClock returns number of moves made since the last capture or pawn move
+func (board *Board) HalfmoveClock() int {
+	return board.halfmoveClock
 }
 
-// IsFieldAttackedByOpposedSide checks whether field at given cords is attacked by any figure of opposed side
-func (board *Board) IsFieldAttackedByOpposedSide(cords Cords, side FigureSide) bool {
-	isAttacked := false
-	for colDelta := -1; colDelta <= 1; colDelta++ {
-		for rowDelta := -1; rowDelta <= 1; rowDelta++ {
-			if colDelta == 0 && rowDelta == 0 {
-				continue
-			}
-			var figuresToSearch mapset.Set[FigureType]
-			if math.Abs(float64(colDelta)) == math.Abs(float64(rowDelta)) {
-				figuresToSearch = diagonalFiguresToSearch
-			} else {
-				figuresToSearch = lineFiguresToSearch
-			}
-			isAttacked = isAttacked || isLineAttacked(board, cords, colDelta, rowDelta, side, figuresToSearch)
-		}
-	}
-	isAttacked = isAttacked || isAttackedByPawn(board, cords, side)
-	isAttacked = isAttacked || isAttackedByKing(board, cords, side)
-	return isAttacked
+// SetHalfmoveClock sets number of moves made since the last capture or pawn move
+func (board *Board) SetHalfmoveClock(halfmoveClock int) {
+	board.halfmoveClock = halfmoveClock
 }
 
-func (board *Board) GetLastMove() Move {
-	if board.lastMove == nil {
-		return nil
-	}
-	return *board.lastMove
+// FullmoveNumber returns number of the current move. It starts at 1 and increases after black moves
+func (board *Board) FullmoveNumber() int {
+	return board.fullmoveNumber
 }
 
-func isAttackedByKing(board *Board, cords Cords, side FigureSide) bool {
-	for row := cords.Row - 1; row <= cords.Row+1; row++ {
-		for col := cords.Col - 1; col <= cords.Col+1; col++ {
-			curCords := Cords{Col: col, Row: row}
-			if curCords == cords || ChessboardSize <= row || row < 0 || ChessboardSize <= col || col < 0 {
-				continue
-			}
-			field := board.GetField(curCords)
-			figure := field.Figure
-			if field.Filled && figure.FigureSide != side && figure.FigureType == King {
-				return true
-			}
-		}
-	}
-	return false
+// SetFullmoveNumber sets number of the current move
+func (board *Board) SetFullmoveNumber(fullmoveNumber int) {
+	board.fullmoveNumber = fullmoveNumber
 }
 
-func isLineAttacked(
-	board *Board,
-	cords Cords,
-	colDelta int,
-	rowDelta int,
-	side FigureSide,
-	figuresToSearch mapset.Set[FigureType],
-) bool {
-	col, row := cords.Col+colDelta, cords.Row+rowDelta
-	for ; 0 <= row && row < ChessboardSize && 0 <= col && col < ChessboardSize; col, row = col+colDelta, row+rowDelta {
-		field := board.GetField(Cords{col, row})
-		if !field.Filled {
-			continue
-		} else if side == field.Figure.FigureSide {
-			return false
-		} else if figuresToSearch.Contains(field.Figure.FigureType) {
-			return true
+// SamePosition checks whether positions are identical for the repetition rule: the same figures stand on the same
+// fields, the same side is to move, and castling and en passant captures are equally possible
+func (board *Board) SamePosition(other *Board) bool {
+	if board.sideToMove != other.sideToMove || board.CastlingRights() != other.CastlingRights() {
+		return false
+	}
+	enPassantCords, capturable := board.capturableEnPassantCords()
+	otherEnPassantCords, otherCapturable := other.capturableEnPassantCords()
+	if capturable != otherCapturable || capturable && enPassantCords != otherEnPassantCords {
+		return false
+	}
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field, otherField := board.board[row][col], other.board[row][col]
+			if field.Filled != otherField.Filled ||
+				field.Filled && (field.Figure.FigureType != otherField.Figure.FigureType ||
+					field.Figure.FigureSide != otherField.Figure.FigureSide) {
+				return false
+			}
 		}
 	}
-	return false
+	return true
 }
 
-func isAttackedByPawn(
-	board *Board,
-	cords Cords,
-	side FigureSide,
-) bool {
-	var rowDelta int
-	if side == White {
-		rowDelta = 1
-	} else {
-		rowDelta = -1
+// capturableEnPassantCords returns en passant cords only if a pawn of the side to move stands next to the pawn
+// which made double step
+func (board *Board) capturableEnPassantCords() (Cords, bool) {
+	enPassantCords, ok := board.doubleStepCords()
+	if !ok {
+		return Cords{}, false
 	}
-
-	if row := cords.Row + rowDelta; 0 <= row && row < ChessboardSize {
-		isAttackedByPawn := false
-		if col := cords.Col - 1; col >= 0 {
-			isAttackedByPawn = isAttackedByPawn || isFieldAttackedByPawn(board, Cords{Col: col, Row: row}, side)
+	pawnCords := board.GetLastMove().Destination().Cords
+	for _, col := range [2]int{pawnCords.Col - 1, pawnCords.Col + 1} {
+		cords := Cords{Col: col, Row: pawnCords.Row}
+		if !isOnBoard(cords) {
+			continue
 		}
-		if col := cords.Col + 1; col < ChessboardSize {
-			isAttackedByPawn = isAttackedByPawn || isFieldAttackedByPawn(board, Cords{Col: col, Row: row}, side)
+		field := board.GetField(cords)
+		if field.Filled && field.Figure.FigureType == Pawn && field.Figure.FigureSide == board.sideToMove {
+			return enPassantCords, true
 		}
-		return isAttackedByPawn
-	} else {
-		return false
 	}
+	return Cords{}, false
 }
 
-func isFieldAttackedByPawn(board *Board, cords Cords, side FigureSide) bool {
-	field := board.GetField(cords)
-	figure := field.Figure
-	return field.Filled && figure.FigureSide != side && figure.FigureType == Pawn
+func (board *Board) GetLastMove() Move {
+	return board.lastMove
 }
 
 // MakeBoard returns initialized board
@@ -201,6 +178,9 @@ func MakeBoard() Board {
 		whiteKingCords: nil,
 		blackKingCords: nil,
 		lastMove:       nil,
+		sideToMove:     White,
+		halfmoveClock:  0,
+		fullmoveNumber: 1,
 	}
 	for row := range board.board {
 		board.board[row] = make([]Field, ChessboardSize)
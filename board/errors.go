@@ -0,0 +1,57 @@
+package board
+
+import "fmt"
+
+type ValidationErrorCode int
+
+const (
+	// UnknownValidationCode is the zero value, it is never reported by the validators
+	UnknownValidationCode    ValidationErrorCode = iota
+	OutOfBordersCode         ValidationErrorCode = iota
+	SameFieldCode            ValidationErrorCode = iota
+	AllyChessmanCode         ValidationErrorCode = iota
+	PathBlockedCode          ValidationErrorCode = iota
+	IllegalFigureMoveCode    ValidationErrorCode = iota
+	KingAttackedCode         ValidationErrorCode = iota
+	PromotionTypeMissingCode ValidationErrorCode = iota
+	PromotionTypeInvalidCode ValidationErrorCode = iota
+	CastlingNotAllowedCode   ValidationErrorCode = iota
+	EmptyDepartureCode       ValidationErrorCode = iota
+	WrongSideCode            ValidationErrorCode = iota
+)
+
+var validationErrorCodeNames = map[ValidationErrorCode]string{
+	OutOfBordersCode:         "departure or destination is out of the board",
+	SameFieldCode:            "departure equals destination",
+	AllyChessmanCode:         "destination is occupied by an ally chessman",
+	PathBlockedCode:          "path to destination is blocked",
+	IllegalFigureMoveCode:    "figure can't move this way",
+	KingAttackedCode:         "king is attacked after move",
+	PromotionTypeMissingCode: "promotion figure is not chosen",
+	PromotionTypeInvalidCode: "pawn can't be promoted to chosen figure",
+	CastlingNotAllowedCode:   "castling is not allowed",
+	EmptyDepartureCode:       "departure field is empty",
+	WrongSideCode:            "figure of the side not to move",
+}
+
+func (code ValidationErrorCode) String() string {
+	if name, ok := validationErrorCodeNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown validation error code %d", int(code))
+}
+
+// ValidationError describes why the move was rejected and which validator rejected it
+type ValidationError struct {
+	Validator string
+	Code      ValidationErrorCode
+	Move      Move
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Validator, err.Code)
+}
+
+func newValidationError(validator string, code ValidationErrorCode, move Move) *ValidationError {
+	return &ValidationError{Validator: validator, Code: code, Move: move}
+}
@@ -0,0 +1,203 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidFEN = errors.New("invalid FEN")
+
+const DefaultFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+var fenFigureTypes = map[byte]FigureType{'k': King, 'q': Queen, 'r': Rook, 'b': Bishop, 'n': Knight, 'p': Pawn}
+
+// ParseFEN returns board described by Forsyth-Edwards Notation. Pawns on their initial rows and kings and rooks
+// keeping castling rights are marked as unmoved, all other figures are marked as moved.
+// The en passant field is represented by the pawn double step as the last move.
+// Move counters may be omitted, they default to "0 1"
+func ParseFEN(fen string) (*Board, error) {
+	parts := strings.Fields(fen)
+	if len(parts) != 4 && len(parts) != 6 {
+		return nil, fmt.Errorf("%w %q: expected 4 or 6 fields, got %d", ErrInvalidFEN, fen, len(parts))
+	}
+	chessBoard := MakeBoard()
+	if err := parseFENFigures(&chessBoard, parts[0]); err != nil {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidFEN, fen, err)
+	}
+
+	switch parts[1] {
+	case "w":
+		chessBoard.SetSideToMove(White)
+	case "b":
+		chessBoard.SetSideToMove(Black)
+	default:
+		return nil, fmt.Errorf("%w %q: unknown side to move %q", ErrInvalidFEN, fen, parts[1])
+	}
+
+	rights, err := parseFENCastling(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidFEN, fen, err)
+	}
+	chessBoard.SetCastlingRights(rights)
+	if chessBoard.CastlingRights() != rights {
+		return nil, fmt.Errorf("%w %q: castling rights %q need kings and rooks on initial fields", ErrInvalidFEN, fen, parts[2])
+	}
+
+	if parts[3] != "-" {
+		enPassantCords, err := parseFENCords(parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidFEN, fen, err)
+		}
+		chessBoard.SetEnPassantCords(&enPassantCords)
+		if !isEnPassantPossible(&chessBoard) {
+			return nil, fmt.Errorf("%w %q: no pawn passed en passant field %s", ErrInvalidFEN, fen, parts[3])
+		}
+	}
+
+	if len(parts) == 6 {
+		halfmoveClock, err := strconv.Atoi(parts[4])
+		if err != nil || halfmoveClock < 0 {
+			return nil, fmt.Errorf("%w %q: invalid halfmove clock %q", ErrInvalidFEN, fen, parts[4])
+		}
+		fullmoveNumber, err := strconv.Atoi(parts[5])
+		if err != nil || fullmoveNumber < 1 {
+			return nil, fmt.Errorf("%w %q: invalid fullmove number %q", ErrInvalidFEN, fen, parts[5])
+		}
+		chessBoard.SetHalfmoveClock(halfmoveClock)
+		chessBoard.SetFullmoveNumber(fullmoveNumber)
+	}
+	return &chessBoard, nil
+}
+
+func parseFENFigures(chessBoard *Board, placement string) error {
+	rows := strings.Split(placement, "/")
+	if len(rows) != ChessboardSize {
+		return fmt.Errorf("expected %d rows, got %d", ChessboardSize, len(rows))
+	}
+	for i, rowPlacement := range rows {
+		row := ChessboardSize - 1 - i
+		col := 0
+		for j := 0; j < len(rowPlacement); j++ {
+			symbol := rowPlacement[j]
+			if symbol >= '1' && symbol <= '8' {
+				col += int(symbol - '0')
+				continue
+			}
+			figureType, known := fenFigureTypes[symbol|0x20]
+			if !known {
+				return fmt.Errorf("unknown figure %q", symbol)
+			}
+			if col >= ChessboardSize {
+				return fmt.Errorf("row %d has more than %d fields", row+1, ChessboardSize)
+			}
+			side := White
+			if symbol >= 'a' {
+				side = Black
+			}
+			moved := figureType != Pawn || side == White && row != 1 || side == Black && row != 6
+			chessBoard.SetField(Field{
+				Figure: Figure{FigureType: figureType, FigureSide: side, Moved: moved},
+				Cords:  Cords{Col: col, Row: row},
+				Filled: true,
+			})
+			col++
+		}
+		if col != ChessboardSize {
+			return fmt.Errorf("row %d doesn't have %d fields", row+1, ChessboardSize)
+		}
+	}
+	return nil
+}
+
+func parseFENCastling(castling string) (CastlingRights, error) {
+	rights := CastlingRights{}
+	if castling == "-" {
+		return rights, nil
+	}
+	for _, symbol := range castling {
+		switch symbol {
+		case 'K':
+			rights.WhiteShort = true
+		case 'Q':
+			rights.WhiteLong = true
+		case 'k':
+			rights.BlackShort = true
+		case 'q':
+			rights.BlackLong = true
+		default:
+			return rights, fmt.Errorf("unknown castling right %q", symbol)
+		}
+	}
+	return rights, nil
+}
+
+func parseFENCords(notation string) (Cords, error) {
+	if len(notation) != 2 || notation[0] < 'a' || notation[0] > 'h' || notation[1] < '1' || notation[1] > '8' {
+		return Cords{}, fmt.Errorf("invalid field %q", notation)
+	}
+	return Cords{Col: int(notation[0] - 'a'), Row: int(notation[1] - '1')}, nil
+}
+
+// FEN returns the position in Forsyth-Edwards Notation
+func (board *Board) FEN() string {
+	var fen strings.Builder
+	for row := ChessboardSize - 1; row >= 0; row-- {
+		empty := 0
+		for col := 0; col < ChessboardSize; col++ {
+			field := board.board[row][col]
+			if !field.Filled {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				fen.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			letter := field.Figure.FigureType.Letter()
+			if field.Figure.FigureType == Pawn {
+				letter = "P"
+			}
+			if field.Figure.FigureSide == Black {
+				letter = strings.ToLower(letter)
+			}
+			fen.WriteString(letter)
+		}
+		if empty > 0 {
+			fen.WriteString(strconv.Itoa(empty))
+		}
+		if row > 0 {
+			fen.WriteByte('/')
+		}
+	}
+
+	if board.sideToMove == Black {
+		fen.WriteString(" b ")
+	} else {
+		fen.WriteString(" w ")
+	}
+
+	rights := board.CastlingRights()
+	castling := ""
+	for _, right := range []struct {
+		allowed bool
+		symbol  string
+	}{{rights.WhiteShort, "K"}, {rights.WhiteLong, "Q"}, {rights.BlackShort, "k"}, {rights.BlackLong, "q"}} {
+		if right.allowed {
+			castling += right.symbol
+		}
+	}
+	if castling == "" {
+		castling = "-"
+	}
+	fen.WriteString(castling)
+
+	if enPassantCords := board.EnPassantCords(); enPassantCords != nil {
+		fen.WriteString(" " + enPassantCords.String())
+	} else {
+		fen.WriteString(" -")
+	}
+	fen.WriteString(" " + strconv.Itoa(board.halfmoveClock) + " " + strconv.Itoa(board.fullmoveNumber))
+	return fen.String()
+}
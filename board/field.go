@@ -14,3 +14,13 @@ type Cords struct {
 func (cords Cords) Equal(cords2 Cords) bool {
 	return cords.Col == cords2.Col && cords.Row == cords2.Row
 }
+
+// IsOnBoard checks whether the cords point to a field of the board
+func (cords Cords) IsOnBoard() bool {
+	return isOnBoard(cords)
+}
+
+// String returns cords in algebraic notation, e.g. "e4"
+func (cords Cords) String() string {
+	return string(rune('a'+cords.Col)) + string(rune('1'+cords.Row))
+}
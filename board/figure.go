@@ -25,3 +25,49 @@ const (
 	White     FigureSide = iota
 	Black     FigureSide = iota
 )
+
+// Opposite returns side of the opponent
+func (side FigureSide) Opposite() FigureSide {
+	switch side {
+	case White:
+		return Black
+	case Black:
+		return White
+	default:
+		return EmptySide
+	}
+}
+
+// Letter returns letter of the figure used in algebraic notation. Pawns have no letter
+func (figureType FigureType) Letter() string {
+	switch figureType {
+	case King:
+		return "K"
+	case Queen:
+		return "Q"
+	case Rook:
+		return "R"
+	case Bishop:
+		return "B"
+	case Knight:
+		return "N"
+	default:
+		return ""
+	}
+}
+
+// Value returns conventional material value of the figure in pawns. King has no material value
+func (figureType FigureType) Value() int {
+	switch figureType {
+	case Pawn:
+		return 1
+	case Knight, Bishop:
+		return 3
+	case Rook:
+		return 5
+	case Queen:
+		return 9
+	default:
+		return 0
+	}
+}
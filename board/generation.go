@@ -1,5 +1,18 @@
 package board
 
+var knightOffsets = []Cords{
+	{Col: 1, Row: 2}, {Col: 2, Row: 1}, {Col: 2, Row: -1}, {Col: 1, Row: -2},
+	{Col: -1, Row: -2}, {Col: -2, Row: -1}, {Col: -2, Row: 1}, {Col: -1, Row: 2},
+}
+
+var kingOffsets = []Cords{
+	{Col: 0, Row: 1}, {Col: 1, Row: 1}, {Col: 1, Row: 0}, {Col: 1, Row: -1},
+	{Col: 0, Row: -1}, {Col: -1, Row: -1}, {Col: -1, Row: 0}, {Col: -1, Row: 1},
+}
+
+// promotionTypes lists figures a pawn can be promoted to in the order moves are generated
+var promotionTypes = []FigureType{Queen, Rook, Bishop, Knight}
+
 type MoveGenerator struct {
 	validatorsMap map[FigureType][]MoveValidator
 }
@@ -9,24 +22,125 @@ func MakeMoveGenerator(validators map[FigureType][]MoveValidator) MoveGenerator
 }
 
 func (moveGenerator MoveGenerator) HasAvailableMoves(chessBoard Board, field Field) bool {
-	for col := 0; col < ChessboardSize; col++ {
-		for row := 0; row < ChessboardSize; row++ {
-			move := MakeMove(field, chessBoard.GetField(Cords{Col: col, Row: row}), EmptyType)
-			if moveGenerator.IsValidMove(move) {
-				return true
+	return len(moveGenerator.fieldLegalMoves(chessBoard, field)) > 0
+}
+
+// LegalMoves returns every legal move of the side to move in given position
+func (moveGenerator MoveGenerator) LegalMoves(position Board) []Move {
+	moves := make([]Move, 0, 40)
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := position.GetField(Cords{Col: col, Row: row})
+			if field.Filled && field.Figure.FigureSide == position.SideToMove() {
+				moves = append(moves, moveGenerator.fieldLegalMoves(position, field)...)
 			}
 		}
 	}
-	return false
+	return moves
+}
+
+// LegalMovesFrom returns every legal move of the figure at given cords.
+// If the figure doesn't belong to the side to move, no moves are returned
+func (moveGenerator MoveGenerator) LegalMovesFrom(position Board, cords Cords) []Move {
+	field := position.GetField(cords)
+	if !field.Filled || field.Figure.FigureSide != position.SideToMove() {
+		return nil
+	}
+	return moveGenerator.fieldLegalMoves(position, field)
+}
+
+func (moveGenerator MoveGenerator) IsValidMove(position *Board, move Move) bool {
+	return moveGenerator.ValidateMove(position, move) == nil
 }
 
-func (moveGenerator MoveGenerator) IsValidMove(move Move) bool {
-	validators := moveGenerator.validatorsMap[move.Departure().Figure.FigureType]
+// ValidateMove runs validators of the moving figure against given position and returns error of the first failed one
+func (moveGenerator MoveGenerator) ValidateMove(position *Board, move Move) error {
+	departure := move.Departure()
+	if !departure.Filled {
+		return newValidationError("MoveGenerator", EmptyDepartureCode, move)
+	}
+	validators := moveGenerator.validatorsMap[departure.Figure.FigureType]
 	for _, validator := range validators {
-		isValid := validator.Validate(move)
-		if !isValid {
-			return false
+		if err := validator.Validate(position, move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (moveGenerator MoveGenerator) fieldLegalMoves(position Board, field Field) []Move {
+	var moves []Move
+	for _, destinationCords := range candidateDestinations(position, field) {
+		destination := position.GetField(destinationCords)
+		if field.Figure.FigureType == Pawn && (destinationCords.Row == 0 || destinationCords.Row == ChessboardSize-1) {
+			for _, promoteToType := range promotionTypes {
+				move := MakeMove(field, destination, promoteToType)
+				if moveGenerator.IsValidMove(&position, move) {
+					moves = append(moves, move)
+				}
+			}
+			continue
+		}
+		move := MakeMove(field, destination, EmptyType)
+		if moveGenerator.IsValidMove(&position, move) {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// candidateDestinations returns cords the figure could reach by its movement pattern.
+// Candidates are not validated, so the list is a superset of legal destinations
+func candidateDestinations(position Board, field Field) []Cords {
+	cords := field.Cords
+	candidates := make([]Cords, 0, 28)
+	addOffsets := func(offsets []Cords) {
+		for _, offset := range offsets {
+			candidate := Cords{Col: cords.Col + offset.Col, Row: cords.Row + offset.Row}
+			if isOnBoard(candidate) {
+				candidates = append(candidates, candidate)
+			}
 		}
 	}
-	return true
+	addRays := func(directions []Direction) {
+		for _, direction := range directions {
+			for _, candidate := range Ray(cords, direction) {
+				candidates = append(candidates, candidate)
+				if position.GetField(candidate).Filled {
+					break
+				}
+			}
+		}
+	}
+
+	switch field.Figure.FigureType {
+	case Pawn:
+		rowDelta := 1
+		if field.Figure.FigureSide == Black {
+			rowDelta = -1
+		}
+		addOffsets([]Cords{
+			{Col: 0, Row: rowDelta},
+			{Col: 0, Row: 2 * rowDelta},
+			{Col: -1, Row: rowDelta},
+			{Col: 1, Row: rowDelta},
+		})
+	case Knight:
+		addOffsets(knightOffsets)
+	case King:
+		addOffsets(kingOffsets)
+		addOffsets([]Cords{{Col: -2, Row: 0}, {Col: 2, Row: 0}})
+	case Rook:
+		addRays(LineDirections)
+	case Bishop:
+		addRays(DiagonalDirections)
+	case Queen:
+		addRays(LineDirections)
+		addRays(DiagonalDirections)
+	}
+	return candidates
+}
+
+func isOnBoard(cords Cords) bool {
+	return 0 <= cords.Col && cords.Col < ChessboardSize && 0 <= cords.Row && cords.Row < ChessboardSize
 }
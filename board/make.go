@@ -0,0 +1,102 @@
+package board
+
+// undoState keeps everything MakeMove changes, so UnmakeMove can restore the board exactly
+type undoState struct {
+	move           Move
+	departure      Field
+	captured       Field
+	rook           Field
+	lastMove       Move
+	sideToMove     FigureSide
+	halfmoveClock  int
+	fullmoveNumber int
+	stateHash      uint64
+	whiteKingCords *Cords
+	blackKingCords *Cords
+}
+
+// MakeMove applies given move to the board in place and remembers how to take it back with UnmakeMove.
+// Boards sharing fields with this one, e.g. its plain value copies, change as well, use Copy to get an independent board
+func (board *Board) MakeMove(move Move) {
+	departureCords := move.Departure().Cords
+	destinationCords := move.Destination().Cords
+	departure := board.GetField(departureCords)
+	capturedCords := destinationCords
+	if isEnPassantMove(move) {
+		capturedCords = enPassantCapturedCords(move)
+	}
+	state := undoState{
+		move:           move,
+		departure:      departure,
+		captured:       board.GetField(capturedCords),
+		lastMove:       board.lastMove,
+		sideToMove:     board.sideToMove,
+		halfmoveClock:  board.halfmoveClock,
+		fullmoveNumber: board.fullmoveNumber,
+		stateHash:      board.stateHash,
+		whiteKingCords: board.whiteKingCords,
+		blackKingCords: board.blackKingCords,
+	}
+
+	movingFigure := departure.Figure
+	movingFigure.Moved = true
+	if castleMove, isCastleMove := move.(CastleMove); isCastleMove {
+		state.rook = board.GetField(castleMove.RookDepartureCords())
+		rook := state.rook.Figure
+		rook.Moved = true
+		board.SetField(Field{Cords: castleMove.RookDepartureCords(), Filled: false})
+		board.SetField(Field{Figure: rook, Cords: castleMove.RookDestinationCords(), Filled: true})
+	} else if promotionMove, isPromotionMove := move.(PromotionMove); isPromotionMove {
+		movingFigure.FigureType = promotionMove.PromoteToType()
+	}
+	if capturedCords != destinationCords {
+		board.SetField(Field{Cords: capturedCords, Filled: false})
+	}
+	board.SetField(Field{Cords: departureCords, Filled: false})
+	board.SetField(Field{Figure: movingFigure, Cords: destinationCords, Filled: true})
+
+	board.stateHash ^= board.enPassantHash()
+	board.lastMove = move
+	board.sideToMove = state.sideToMove.Opposite()
+	board.stateHash ^= blackToMove ^ board.enPassantHash()
+	if departure.Figure.FigureType == Pawn || state.captured.Filled {
+		board.halfmoveClock = 0
+	} else {
+		board.halfmoveClock++
+	}
+	if departure.Figure.FigureSide == Black {
+		board.fullmoveNumber++
+	}
+	board.undoStack = append(board.undoStack, state)
+}
+
+// UnmakeMove takes back the last move made by MakeMove and returns it. It returns nil if there is nothing to take back
+func (board *Board) UnmakeMove() Move {
+	if len(board.undoStack) == 0 {
+		return nil
+	}
+	state := board.undoStack[len(board.undoStack)-1]
+	board.undoStack = board.undoStack[:len(board.undoStack)-1]
+
+	board.SetField(Field{Cords: state.move.Destination().Cords, Filled: false})
+	board.SetField(state.captured)
+	board.SetField(state.departure)
+	if castleMove, isCastleMove := state.move.(CastleMove); isCastleMove {
+		board.SetField(Field{Cords: castleMove.RookDestinationCords(), Filled: false})
+		board.SetField(state.rook)
+	}
+
+	board.whiteKingCords = state.whiteKingCords
+	board.blackKingCords = state.blackKingCords
+	board.lastMove = state.lastMove
+	board.sideToMove = state.sideToMove
+	board.stateHash = state.stateHash
+	board.halfmoveClock = state.halfmoveClock
+	board.fullmoveNumber = state.fullmoveNumber
+	return state.move
+}
+
+// MadeMoves returns number of moves which can be taken back by UnmakeMove
+func (board *Board) MadeMoves() int {
+	return len(board.undoStack)
+}
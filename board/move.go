@@ -1,6 +1,9 @@
 package board
 
-import "math"
+import (
+	"math"
+	"strings"
+)
 
 type Move interface {
 	Departure() Field
@@ -8,22 +11,18 @@ type Move interface {
 	String() string
 }
 
-// MakeMove TODO: make representation
+// MoveFields is the part of a move telling where the figure moves from and to. Functions accepting it take
+// both Move and GeneratedMove. Pass a pointer to a generated move, so the move isn't copied to the heap
+type MoveFields interface {
+	Departure() Field
+	Destination() Field
+}
+
+// MakeMove returns move of the figure from departure to destination. Castling and promotion are recognized
+// by the moving figure and the destination
 func MakeMove(departure Field, destination Field, promoteToType FigureType) Move {
-	colDistance := math.Abs(float64(departure.Cords.Col - destination.Cords.Col))
-	if departure.Figure.FigureType == King && colDistance > 1 {
-		row := GetDefaultRowBySide(departure.Figure.FigureSide)
-		var rookDepartureCords Cords
-		var rookDestinationCords Cords
-		if destination.Cords.Col == 2 {
-			// long side castle
-			rookDepartureCords = Cords{Col: 0, Row: row}
-			rookDestinationCords = Cords{Col: 3, Row: row}
-		} else {
-			// short side castle
-			rookDepartureCords = Cords{Col: 7, Row: row}
-			rookDestinationCords = Cords{Col: 5, Row: row}
-		}
+	if isCastling(departure, destination) {
+		rookDepartureCords, rookDestinationCords := castlingRookCords(departure, destination)
 		return CastleMove{
 			departure:            departure,
 			destination:          destination,
@@ -43,6 +42,75 @@ func MakeMove(departure Field, destination Field, promoteToType FigureType) Move
 	}
 }
 
+func isCastling(departure Field, destination Field) bool {
+	colDistance := math.Abs(float64(departure.Cords.Col - destination.Cords.Col))
+	return departure.Figure.FigureType == King && colDistance > 1
+}
+
+// castlingRookCords returns where the rook moves from and to when the king castles
+func castlingRookCords(king Field, destination Field) (Cords, Cords) {
+	row := GetDefaultRowBySide(king.Figure.FigureSide)
+	if destination.Cords.Col == 2 {
+		// long side castle
+		return Cords{Col: 0, Row: row}, Cords{Col: 3, Row: row}
+	}
+	// short side castle
+	return Cords{Col: 7, Row: row}, Cords{Col: 5, Row: row}
+}
+
+// PromotionOf returns the type the pawn is promoted to by a promotion or generated move, or EmptyType otherwise
+func PromotionOf(move MoveFields) FigureType {
+	if promotion, isPromotion := move.(interface{ PromoteToType() FigureType }); isPromotion {
+		return promotion.PromoteToType()
+	}
+	return EmptyType
+}
+
+// GeneratedMove is a move kept by value, so the staged generator can write moves into its buffer
+// without allocating. Move converts it to the Move taken by the rest of the board API
+type GeneratedMove struct {
+	departure     Field
+	destination   Field
+	promoteToType FigureType
+}
+
+func (move GeneratedMove) Departure() Field {
+	return move.departure
+}
+
+func (move GeneratedMove) Destination() Field {
+	return move.destination
+}
+
+// PromoteToType returns the type the pawn is promoted to, or EmptyType if the move is not a promotion
+func (move GeneratedMove) PromoteToType() FigureType {
+	return move.promoteToType
+}
+
+// Move returns the move as made by MakeMove, recognizing castling and promotion
+func (move GeneratedMove) Move() Move {
+	return MakeMove(move.departure, move.destination, move.promoteToType)
+}
+
+// isEnPassantMove checks whether pawn moves diagonally to an empty field, i.e. captures en passant
+func isEnPassantMove(move MoveFields) bool {
+	departure := move.Departure()
+	return departure.Figure.FigureType == Pawn &&
+		departure.Cords.Col != move.Destination().Cords.Col &&
+		!move.Destination().Filled
+}
+
+// enPassantCapturedCords returns cords of the pawn captured by en passant move
+func enPassantCapturedCords(move MoveFields) Cords {
+	return Cords{Col: move.Destination().Cords.Col, Row: move.Departure().Cords.Row}
+}
+
+// coordinateNotation returns move as departure and destination cords followed by the promotion figure,
+// e.g. "e2e4" or "e7e8q"
+func coordinateNotation(move Move, promoteToType FigureType) string {
+	return move.Departure().Cords.String() + move.Destination().Cords.String() + strings.ToLower(promoteToType.Letter())
+}
+
 type DefaultMove struct {
 	departure            Field
 	destination          Field
@@ -58,6 +126,9 @@ func (move DefaultMove) Destination() Field {
 }
 
 func (move DefaultMove) String() string {
+	if move.stringRepresentation == "" {
+		return coordinateNotation(move, EmptyType)
+	}
 	return move.stringRepresentation
 }
 
@@ -70,6 +141,9 @@ type CastleMove struct {
 }
 
 func (move CastleMove) String() string {
+	if move.stringRepresentation == "" {
+		return coordinateNotation(move, EmptyType)
+	}
 	return move.stringRepresentation
 }
 
@@ -105,6 +179,9 @@ func (move PromotionMove) Destination() Field {
 }
 
 func (move PromotionMove) String() string {
+	if move.stringRepresentation == "" {
+		return coordinateNotation(move, move.promoteToType)
+	}
 	return move.stringRepresentation
 }
 
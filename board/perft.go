@@ -0,0 +1,41 @@
+package board
+
+// PerftResult is number of leaf positions reached after the move
+type PerftResult struct {
+	Move  Move
+	Nodes int
+}
+
+// Perft counts positions reachable from given one by exactly depth legal moves.
+// Moves are made on the position in place and taken back, so it ends up unchanged
+func (moveGenerator MoveGenerator) Perft(position *Board, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := moveGenerator.LegalMoves(*position)
+	if depth == 1 {
+		return len(moves)
+	}
+	nodes := 0
+	for _, move := range moves {
+		position.MakeMove(move)
+		nodes += moveGenerator.Perft(position, depth-1)
+		position.UnmakeMove()
+	}
+	return nodes
+}
+
+// Divide is Perft split by the first move
+func (moveGenerator MoveGenerator) Divide(position *Board, depth int) []PerftResult {
+	if depth == 0 {
+		return nil
+	}
+	moves := moveGenerator.LegalMoves(*position)
+	results := make([]PerftResult, 0, len(moves))
+	for _, move := range moves {
+		position.MakeMove(move)
+		results = append(results, PerftResult{Move: move, Nodes: moveGenerator.Perft(position, depth-1)})
+		position.UnmakeMove()
+	}
+	return results
+}
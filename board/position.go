@@ -0,0 +1,329 @@
+package board
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CastlingRights shows which castles are still possible by the figures having not moved yet
+type CastlingRights struct {
+	WhiteShort bool
+	WhiteLong  bool
+	BlackShort bool
+	BlackLong  bool
+}
+
+// CastlingRights returns castling rights derived from the king and the rooks standing unmoved on their initial fields
+func (board *Board) CastlingRights() CastlingRights {
+	return CastlingRights{
+		WhiteShort: board.hasCastlingRight(White, 7),
+		WhiteLong:  board.hasCastlingRight(White, 0),
+		BlackShort: board.hasCastlingRight(Black, 7),
+		BlackLong:  board.hasCastlingRight(Black, 0),
+	}
+}
+
+func (board *Board) hasCastlingRight(side FigureSide, rookCol int) bool {
+	row := GetDefaultRowBySide(side)
+	king := board.GetField(Cords{Col: 4, Row: row})
+	rook := board.GetField(Cords{Col: rookCol, Row: row})
+	return king.Filled && king.Figure == Figure{FigureType: King, FigureSide: side} &&
+		rook.Filled && rook.Figure == Figure{FigureType: Rook, FigureSide: side}
+}
+
+// EnPassantCords returns field skipped by the pawn on the last move, or nil if the last move wasn't a pawn double step
+func (board *Board) EnPassantCords() *Cords {
+	if cords, ok := board.doubleStepCords(); ok {
+		return &cords
+	}
+	return nil
+}
+
+// doubleStepCords returns the same field as EnPassantCords without allocating it
+func (board *Board) doubleStepCords() (Cords, bool) {
+	lastMove := board.GetLastMove()
+	if lastMove == nil || lastMove.Departure().Figure.FigureType != Pawn {
+		return Cords{}, false
+	}
+	departureCords := lastMove.Departure().Cords
+	destinationCords := lastMove.Destination().Cords
+	if math.Abs(float64(destinationCords.Row-departureCords.Row)) != 2 || departureCords.Col != destinationCords.Col {
+		return Cords{}, false
+	}
+	return Cords{Col: departureCords.Col, Row: (departureCords.Row + destinationCords.Row) / 2}, true
+}
+
+// SetCastlingRights marks the kings and the rooks standing on their initial fields as unmoved
+// if they keep given castling rights, and as moved otherwise
+func (board *Board) SetCastlingRights(rights CastlingRights) {
+	board.setCastlingRight(White, rights.WhiteShort, rights.WhiteLong)
+	board.setCastlingRight(Black, rights.BlackShort, rights.BlackLong)
+}
+
+func (board *Board) setCastlingRight(side FigureSide, short bool, long bool) {
+	row := GetDefaultRowBySide(side)
+	for col, moved := range map[int]bool{0: !long, 4: !short && !long, 7: !short} {
+		field := board.GetField(Cords{Col: col, Row: row})
+		expectedType := Rook
+		if col == 4 {
+			expectedType = King
+		}
+		if field.Filled && field.Figure.FigureType == expectedType && field.Figure.FigureSide == side {
+			field.Figure.Moved = moved
+			board.SetField(field)
+		}
+	}
+}
+
+// SetEnPassantCords makes the last move a double step of the pawn of the side not to move over given field,
+// so the pawn can be captured en passant. nil clears the last move. The side to move has to be set beforehand
+func (board *Board) SetEnPassantCords(cords *Cords) {
+	board.stateHash ^= board.enPassantHash()
+	if cords == nil {
+		board.lastMove = nil
+		return
+	}
+	side := board.sideToMove.Opposite()
+	direction := 1
+	if side == Black {
+		direction = -1
+	}
+	pawn := Figure{FigureType: Pawn, FigureSide: side}
+	board.lastMove = DefaultMove{
+		departure:   Field{Figure: pawn, Cords: Cords{Col: cords.Col, Row: cords.Row - direction}, Filled: true},
+		destination: Field{Cords: Cords{Col: cords.Col, Row: cords.Row + direction}},
+	}
+	board.stateHash ^= board.enPassantHash()
+}
+
+type PositionProblemCode int
+
+const (
+	MissingKingCode              PositionProblemCode = iota
+	ExtraKingCode                PositionProblemCode = iota
+	PawnOnBackRankCode           PositionProblemCode = iota
+	SideNotToMoveInCheckCode     PositionProblemCode = iota
+	ImpossibleCastlingRightsCode PositionProblemCode = iota
+	ImpossibleEnPassantCode      PositionProblemCode = iota
+	TooManyFiguresCode           PositionProblemCode = iota
+	TooManyPawnsCode             PositionProblemCode = iota
+	TooManyPromotedFiguresCode   PositionProblemCode = iota
+	UnmovedPawnOffStartRowCode   PositionProblemCode = iota
+)
+
+var positionProblemCodeNames = map[PositionProblemCode]string{
+	MissingKingCode:              "king is missing",
+	ExtraKingCode:                "more than one king",
+	PawnOnBackRankCode:           "pawn on the back rank",
+	SideNotToMoveInCheckCode:     "side not to move is in check",
+	ImpossibleCastlingRightsCode: "impossible castling rights",
+	ImpossibleEnPassantCode:      "impossible en passant",
+	TooManyFiguresCode:           "more than 16 figures",
+	TooManyPawnsCode:             "more than 8 pawns",
+	TooManyPromotedFiguresCode:   "more promoted figures than missing pawns",
+	UnmovedPawnOffStartRowCode:   "unmoved pawn off its start row",
+}
+
+func (code PositionProblemCode) String() string {
+	if name, ok := positionProblemCodeNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown position problem code %d", int(code))
+}
+
+// PositionProblem describes single reason why the position can't occur in a legal game
+type PositionProblem struct {
+	Code  PositionProblemCode
+	Side  FigureSide
+	Cords *Cords
+}
+
+func (problem PositionProblem) Error() string {
+	var sideName string
+	switch problem.Side {
+	case White:
+		sideName = "white"
+	case Black:
+		sideName = "black"
+	}
+	message := problem.Code.String()
+	if sideName != "" {
+		message = sideName + ": " + message
+	}
+	if problem.Cords != nil {
+		message = fmt.Sprintf("%s at col %d row %d", message, problem.Cords.Col, problem.Cords.Row)
+	}
+	return message
+}
+
+// PositionError holds all problems found in the position
+type PositionError struct {
+	Problems []PositionProblem
+}
+
+func (err *PositionError) Error() string {
+	messages := make([]string, 0, len(err.Problems))
+	for _, problem := range err.Problems {
+		messages = append(messages, problem.Error())
+	}
+	return "illegal position: " + strings.Join(messages, "; ")
+}
+
+// ValidatePosition checks that the position can occur in a legal game and returns all problems found
+func ValidatePosition(position *Board) []PositionProblem {
+	var problems []PositionProblem
+	for _, side := range []FigureSide{White, Black} {
+		problems = append(problems, validateFigures(position, side)...)
+	}
+
+	sideNotToMove := position.SideToMove().Opposite()
+	if kingCords := position.findKing(sideNotToMove); kingCords != nil &&
+		position.IsFieldAttackedByOpposedSide(*kingCords, sideNotToMove) {
+		problems = append(problems, PositionProblem{Code: SideNotToMoveInCheckCode, Side: sideNotToMove, Cords: kingCords})
+	}
+
+	if !isEnPassantPossible(position) {
+		problems = append(problems, PositionProblem{
+			Code:  ImpossibleEnPassantCode,
+			Side:  sideNotToMove,
+			Cords: position.EnPassantCords(),
+		})
+	}
+	return problems
+}
+
+func validateFigures(position *Board, side FigureSide) []PositionProblem {
+	var problems []PositionProblem
+	counts := make(map[FigureType]int, 6)
+	figuresCount := 0
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := position.GetField(Cords{Col: col, Row: row})
+			if !field.Filled || field.Figure.FigureSide != side {
+				continue
+			}
+			cords := field.Cords
+			figuresCount++
+			counts[field.Figure.FigureType]++
+			switch field.Figure.FigureType {
+			case Pawn:
+				if row == 0 || row == ChessboardSize-1 {
+					problems = append(problems, PositionProblem{Code: PawnOnBackRankCode, Side: side, Cords: &cords})
+				} else if !field.Figure.Moved && row != pawnStartRow(side) {
+					// such a pawn could make a double step it isn't allowed to
+					problems = append(problems, PositionProblem{Code: UnmovedPawnOffStartRowCode, Side: side, Cords: &cords})
+				}
+			case King:
+				if counts[King] > 1 {
+					problems = append(problems, PositionProblem{Code: ExtraKingCode, Side: side, Cords: &cords})
+				}
+				if !field.Figure.Moved && cords != (Cords{Col: 4, Row: GetDefaultRowBySide(side)}) {
+					problems = append(problems, PositionProblem{Code: ImpossibleCastlingRightsCode, Side: side, Cords: &cords})
+				}
+			}
+		}
+	}
+
+	if counts[King] == 0 {
+		problems = append(problems, PositionProblem{Code: MissingKingCode, Side: side})
+	}
+	if figuresCount > 2*ChessboardSize {
+		problems = append(problems, PositionProblem{Code: TooManyFiguresCode, Side: side})
+	}
+	if counts[Pawn] > ChessboardSize {
+		problems = append(problems, PositionProblem{Code: TooManyPawnsCode, Side: side})
+	}
+	promoted := max(0, counts[Queen]-1) + max(0, counts[Rook]-2) + max(0, counts[Bishop]-2) + max(0, counts[Knight]-2)
+	if promoted > ChessboardSize-counts[Pawn] {
+		problems = append(problems, PositionProblem{Code: TooManyPromotedFiguresCode, Side: side})
+	}
+	return problems
+}
+
+func pawnStartRow(side FigureSide) int {
+	if side == White {
+		return 1
+	}
+	return ChessboardSize - 2
+}
+
+// isEnPassantPossible checks that the pawn which made double step stands on its destination
+// and was moved by the side not to move
+func isEnPassantPossible(position *Board) bool {
+	enPassantCords := position.EnPassantCords()
+	if enPassantCords == nil {
+		return true
+	}
+	lastMove := position.GetLastMove()
+	pawnField := position.GetField(lastMove.Destination().Cords)
+	return pawnField.Filled &&
+		pawnField.Figure.FigureType == Pawn &&
+		pawnField.Figure.FigureSide == position.SideToMove().Opposite() &&
+		!position.GetField(*enPassantCords).Filled &&
+		!position.GetField(lastMove.Departure().Cords).Filled
+}
+
+func (board *Board) findKing(side FigureSide) *Cords {
+	kingCords := board.GetKingCords(side)
+	if kingCords != nil {
+		field := board.GetField(*kingCords)
+		if field.Filled && field.Figure.FigureType == King && field.Figure.FigureSide == side {
+			return kingCords
+		}
+	}
+	return nil
+}
+
+// HasMatingMaterial checks whether given side can checkmate the opponent by any series of legal moves.
+// Lone king can't mate. A single knight, or bishops all on the same color fields, can mate only if the opponent
+// has a figure to block its own king: any figure for the knight, and a figure other than bishops
+// on the same color fields for the bishops
+func (board *Board) HasMatingMaterial(side FigureSide) bool {
+	own := board.materialOf(side)
+	switch {
+	case own.other || own.knights > 1 || len(own.bishopFieldColors) == 2 || own.knights == 1 && len(own.bishopFieldColors) > 0:
+		return true
+	case own.knights == 0 && len(own.bishopFieldColors) == 0:
+		return false
+	}
+
+	opponent := board.materialOf(side.Opposite())
+	if own.knights == 1 {
+		return opponent.other || opponent.knights > 0 || len(opponent.bishopFieldColors) > 0
+	}
+	for color := range opponent.bishopFieldColors {
+		if !own.bishopFieldColors[color] {
+			return true
+		}
+	}
+	return opponent.other || opponent.knights > 0
+}
+
+// material counts figures of a side which matter for mating: pawns, rooks and queens are counted as other
+type material struct {
+	other             bool
+	knights           int
+	bishopFieldColors map[int]bool
+}
+
+func (board *Board) materialOf(side FigureSide) material {
+	summary := material{bishopFieldColors: make(map[int]bool, 2)}
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := board.GetField(Cords{Col: col, Row: row})
+			if !field.Filled || field.Figure.FigureSide != side {
+				continue
+			}
+			switch field.Figure.FigureType {
+			case Pawn, Rook, Queen:
+				summary.other = true
+			case Knight:
+				summary.knights++
+			case Bishop:
+				summary.bishopFieldColors[(col+row)%2] = true
+			}
+		}
+	}
+	return summary
+}
@@ -0,0 +1,121 @@
+package board
+
+// Direction is a single step of a ray going along a line or a diagonal
+type Direction struct {
+	ColDelta int
+	RowDelta int
+}
+
+var LineDirections = []Direction{
+	{ColDelta: 0, RowDelta: 1},
+	{ColDelta: 1, RowDelta: 0},
+	{ColDelta: 0, RowDelta: -1},
+	{ColDelta: -1, RowDelta: 0},
+}
+
+var DiagonalDirections = []Direction{
+	{ColDelta: 1, RowDelta: 1},
+	{ColDelta: 1, RowDelta: -1},
+	{ColDelta: -1, RowDelta: -1},
+	{ColDelta: -1, RowDelta: 1},
+}
+
+// IsDiagonal checks whether the direction goes along a diagonal
+func (direction Direction) IsDiagonal() bool {
+	return direction.ColDelta != 0 && direction.RowDelta != 0
+}
+
+// Opposite returns direction pointing backwards
+func (direction Direction) Opposite() Direction {
+	return Direction{ColDelta: -direction.ColDelta, RowDelta: -direction.RowDelta}
+}
+
+// Next returns cords one step further along the direction. The result may be out of the board
+func (direction Direction) Next(cords Cords) Cords {
+	return Cords{Col: cords.Col + direction.ColDelta, Row: cords.Row + direction.RowDelta}
+}
+
+// isSlider checks whether figure of given type moves along the direction any number of fields
+func (direction Direction) isSlider(figureType FigureType) bool {
+	if direction.IsDiagonal() {
+		return figureType == Queen || figureType == Bishop
+	}
+	return figureType == Queen || figureType == Rook
+}
+
+// DirectionBetween returns direction leading from one cords to another if they share a line or a diagonal
+func DirectionBetween(from Cords, to Cords) (Direction, bool) {
+	colDiff := to.Col - from.Col
+	rowDiff := to.Row - from.Row
+	if from == to || (colDiff != 0 && rowDiff != 0 && abs(colDiff) != abs(rowDiff)) {
+		return Direction{}, false
+	}
+	return Direction{ColDelta: sign(colDiff), RowDelta: sign(rowDiff)}, true
+}
+
+// Between returns cords strictly between two aligned cords, ordered from the first to the second one.
+// If cords aren't aligned, nil is returned
+func Between(from Cords, to Cords) []Cords {
+	direction, aligned := DirectionBetween(from, to)
+	if !aligned {
+		return nil
+	}
+	var between []Cords
+	for cords := direction.Next(from); cords != to; cords = direction.Next(cords) {
+		between = append(between, cords)
+	}
+	return between
+}
+
+// Ray returns cords from the given one (exclusively) to the board edge along the direction
+func Ray(from Cords, direction Direction) []Cords {
+	var ray []Cords
+	for cords := direction.Next(from); isOnBoard(cords); cords = direction.Next(cords) {
+		ray = append(ray, cords)
+	}
+	return ray
+}
+
+// FirstBlocker returns the first filled field along the ray starting next to given cords
+func (board *Board) FirstBlocker(from Cords, direction Direction) (Field, bool) {
+	return firstBlocker(board, from, direction)
+}
+
+func firstBlocker(view fieldView, from Cords, direction Direction) (Field, bool) {
+	for cords := direction.Next(from); isOnBoard(cords); cords = direction.Next(cords) {
+		if field := view.GetField(cords); field.Filled {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// IsPathClear checks that all fields between two aligned cords are empty
+func (board *Board) IsPathClear(from Cords, to Cords) bool {
+	direction, aligned := DirectionBetween(from, to)
+	if !aligned {
+		return true
+	}
+	for cords := direction.Next(from); cords != to; cords = direction.Next(cords) {
+		if board.GetField(cords).Filled {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func sign(value int) int {
+	if value > 0 {
+		return 1
+	} else if value < 0 {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package board
+
+import "strings"
+
+// CapturedFigure returns figure captured by given move, including the pawn captured en passant
+func (board *Board) CapturedFigure(move MoveFields) (Figure, bool) {
+	if isEnPassantMove(move) {
+		return board.GetField(enPassantCapturedCords(move)).Figure, true
+	}
+	destination := board.GetField(move.Destination().Cords)
+	return destination.Figure, destination.Filled
+}
+
+// Material returns total value of figures of given side
+func (board *Board) Material(side FigureSide) int {
+	material := 0
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := board.board[row][col]
+			if field.Filled && field.Figure.FigureSide == side {
+				material += field.Figure.FigureType.Value()
+			}
+		}
+	}
+	return material
+}
+
+// SAN returns given legal move in standard algebraic notation, e.g. "Nbd7", "exd6", "e8=Q+" or "O-O#"
+func (moveGenerator MoveGenerator) SAN(position Board, move Move) string {
+	var san strings.Builder
+	departure := move.Departure()
+	destinationCords := move.Destination().Cords
+	_, isCapture := position.CapturedFigure(move)
+
+	if castleMove, isCastleMove := move.(CastleMove); isCastleMove {
+		if castleMove.RookDepartureCords().Col == 0 {
+			san.WriteString("O-O-O")
+		} else {
+			san.WriteString("O-O")
+		}
+	} else if departure.Figure.FigureType == Pawn {
+		if isCapture {
+			san.WriteByte(departure.Cords.String()[0])
+			san.WriteByte('x')
+		}
+		san.WriteString(destinationCords.String())
+		if promotionMove, isPromotionMove := move.(PromotionMove); isPromotionMove {
+			san.WriteByte('=')
+			san.WriteString(promotionMove.PromoteToType().Letter())
+		}
+	} else {
+		san.WriteString(departure.Figure.FigureType.Letter())
+		san.WriteString(moveGenerator.disambiguation(position, move))
+		if isCapture {
+			san.WriteByte('x')
+		}
+		san.WriteString(destinationCords.String())
+	}
+
+	nextPosition := position.Move(move)
+	if nextPosition.IsInCheck(nextPosition.SideToMove()) {
+		if len(moveGenerator.LegalMoves(nextPosition)) == 0 {
+			san.WriteByte('#')
+		} else {
+			san.WriteByte('+')
+		}
+	}
+	return san.String()
+}
+
+// disambiguation returns departure file, rank or both if other figures of the same type can reach the destination
+func (moveGenerator MoveGenerator) disambiguation(position Board, move Move) string {
+	departure := move.Departure()
+	ambiguous, sameCol, sameRow := false, false, false
+	for _, other := range moveGenerator.LegalMoves(position) {
+		otherDeparture := other.Departure()
+		if otherDeparture.Figure.FigureType != departure.Figure.FigureType ||
+			otherDeparture.Cords == departure.Cords ||
+			other.Destination().Cords != move.Destination().Cords {
+			continue
+		}
+		ambiguous = true
+		sameCol = sameCol || otherDeparture.Cords.Col == departure.Cords.Col
+		sameRow = sameRow || otherDeparture.Cords.Row == departure.Cords.Row
+	}
+	cords := departure.Cords.String()
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameCol:
+		return cords[:1]
+	case !sameRow:
+		return cords[1:]
+	default:
+		return cords
+	}
+}
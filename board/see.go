@@ -0,0 +1,119 @@
+package board
+
+// seeKingValue makes the king the most valuable attacker, so it recaptures last
+const seeKingValue = 100
+
+// exchangeView hides figures which already took part in the exchange, uncovering sliders standing behind them
+type exchangeView struct {
+	board   *Board
+	removed [ChessboardSize][ChessboardSize]bool
+}
+
+func (view *exchangeView) GetField(cords Cords) Field {
+	if view.removed[cords.Row][cords.Col] {
+		return Field{Cords: cords}
+	}
+	return view.board.GetField(cords)
+}
+
+func (view *exchangeView) remove(cords Cords) {
+	view.removed[cords.Row][cords.Col] = true
+}
+
+// leastValuableAttacker returns the cheapest figure of given side attacking the cords
+func (view *exchangeView) leastValuableAttacker(cords Cords, side FigureSide) (Field, bool) {
+	var cheapest Field
+	found := false
+	visitAttackers(view, cords, side, func(attacker Field) bool {
+		if !found || SEEValue(attacker.Figure.FigureType) < SEEValue(cheapest.Figure.FigureType) {
+			cheapest, found = attacker, true
+		}
+		return attacker.Figure.FigureType != Pawn
+	})
+	return cheapest, found
+}
+
+// SEE returns material won by the moving side, in pawns as FigureType.Value counts them, if both sides keep
+// recapturing on the destination with their least valuable figure while it pays off. Sliders standing behind
+// the recapturing figures join the exchange. A pawn recapturing on the last row is promoted to a queen.
+// The king recaptures only if the opponent can't capture it back. Pins are not taken into account
+func (board *Board) SEE(move MoveFields) int {
+	target := move.Destination().Cords
+	view := &exchangeView{board: board}
+	gains := make([]int, 1, 32)
+	gains[0] = board.capturedValue(move)
+	onTarget := SEEValue(move.Departure().Figure.FigureType)
+	if promoteToType := PromotionOf(move); promoteToType != EmptyType {
+		gains[0] += promoteToType.Value() - Pawn.Value()
+		onTarget = promoteToType.Value()
+	}
+	view.remove(move.Departure().Cords)
+	if isEnPassantMove(move) {
+		view.remove(enPassantCapturedCords(move))
+	}
+
+	side := move.Departure().Figure.FigureSide.Opposite()
+	for {
+		attacker, found := view.leastValuableAttacker(target, side)
+		if !found {
+			break
+		}
+		if attacker.Figure.FigureType == King {
+			view.remove(attacker.Cords)
+			if _, defended := view.leastValuableAttacker(target, side.Opposite()); defended {
+				break
+			}
+		}
+		gain := onTarget - gains[len(gains)-1]
+		onTarget = SEEValue(attacker.Figure.FigureType)
+		if attacker.Figure.FigureType == Pawn && (target.Row == 0 || target.Row == ChessboardSize-1) {
+			gain += Queen.Value() - Pawn.Value()
+			onTarget = Queen.Value()
+		}
+		gains = append(gains, gain)
+		view.remove(attacker.Cords)
+		side = side.Opposite()
+	}
+
+	// every side may stop capturing, so a capture is made only if it doesn't lose material
+	for i := len(gains) - 1; i > 0; i-- {
+		gains[i-1] = -max(-gains[i-1], gains[i])
+	}
+	return gains[0]
+}
+
+// SEEAtLeast checks whether SEE of the move is not lower than threshold. Most moves are decided
+// without playing the exchange out: the move can't win more than it captures, and unless the opponent
+// can recapture with promotion, it can't lose more than the figure it puts on the destination
+func (board *Board) SEEAtLeast(move MoveFields, threshold int) bool {
+	targetRow := move.Destination().Cords.Row
+	captured := board.capturedValue(move)
+	moving := SEEValue(move.Departure().Figure.FigureType)
+	if promoteToType := PromotionOf(move); promoteToType != EmptyType {
+		captured += promoteToType.Value() - Pawn.Value()
+		moving = promoteToType.Value()
+	}
+	if captured < threshold {
+		return false
+	}
+	if captured-moving >= threshold && targetRow != 0 && targetRow != ChessboardSize-1 {
+		return true
+	}
+	return board.SEE(move) >= threshold
+}
+
+func (board *Board) capturedValue(move MoveFields) int {
+	if captured, isCapture := board.CapturedFigure(move); isCapture {
+		return captured.FigureType.Value()
+	}
+	return 0
+}
+
+// SEEValue returns value of the figure as an attacker in the exchange: FigureType.Value, but the king
+// is the most valuable attacker
+func SEEValue(figureType FigureType) int {
+	if figureType == King {
+		return seeKingValue
+	}
+	return figureType.Value()
+}
@@ -0,0 +1,276 @@
+package board
+
+type GenerationStage int
+
+const (
+	// CapturesStage yields captures, including en passant, and promotions
+	CapturesStage GenerationStage = iota
+	// QuietsStage yields all other moves including castling
+	QuietsStage GenerationStage = iota
+	// EvasionsStage replaces both stages when the king is in check
+	EvasionsStage GenerationStage = iota
+	DoneStage     GenerationStage = iota
+)
+
+// StagedMoveGenerator generates moves of the side to move stage by stage straight from the movement patterns
+// of the figures, without running the validator chain. Pseudo-legal moves are filtered by checkers and pins
+// found once per position. Moves are written by value into the buffer kept between positions, so a reused
+// generator doesn't allocate
+type StagedMoveGenerator struct {
+	position       *Board
+	buffer         []GeneratedMove
+	stage          GenerationStage
+	side           FigureSide
+	kingCords      *Cords
+	checkers       []Field
+	pinDirections  [ChessboardSize][ChessboardSize]Direction
+	evasionTargets [ChessboardSize][ChessboardSize]bool
+	view           moveView
+}
+
+func MakeStagedMoveGenerator() *StagedMoveGenerator {
+	return &StagedMoveGenerator{buffer: make([]GeneratedMove, 0, 256), checkers: make([]Field, 0, 2)}
+}
+
+// Reset starts generation for given position. Moves returned before are overwritten
+func (generator *StagedMoveGenerator) Reset(position *Board) {
+	generator.position = position
+	generator.buffer = generator.buffer[:0]
+	generator.side = position.SideToMove()
+	generator.kingCords = position.findKing(generator.side)
+	generator.checkers = generator.checkers[:0]
+	generator.pinDirections = [ChessboardSize][ChessboardSize]Direction{}
+	generator.stage = CapturesStage
+	if generator.kingCords == nil {
+		return
+	}
+	kingCords := *generator.kingCords
+	visitAttackers(position, kingCords, generator.side.Opposite(), func(checker Field) bool {
+		generator.checkers = append(generator.checkers, checker)
+		return true
+	})
+	for _, directions := range [2][]Direction{LineDirections, DiagonalDirections} {
+		for _, direction := range directions {
+			if pin, pinned := position.pinAlong(kingCords, direction, generator.side); pinned {
+				generator.pinDirections[pin.Pinned.Cords.Row][pin.Pinned.Cords.Col] = pin.Direction
+			}
+		}
+	}
+	if len(generator.checkers) > 0 {
+		generator.stage = EvasionsStage
+		generator.evasionTargets = [ChessboardSize][ChessboardSize]bool{}
+		if len(generator.checkers) == 1 {
+			checkerCords := generator.checkers[0].Cords
+			generator.evasionTargets[checkerCords.Row][checkerCords.Col] = true
+			if direction, aligned := DirectionBetween(kingCords, checkerCords); aligned {
+				for cords := direction.Next(kingCords); cords != checkerCords; cords = direction.Next(cords) {
+					generator.evasionTargets[cords.Row][cords.Col] = true
+				}
+			}
+		}
+	}
+}
+
+// NextStage returns legal moves of the next stage. The moves stay valid until the next Reset.
+// It returns false once all stages are generated
+func (generator *StagedMoveGenerator) NextStage() (GenerationStage, []GeneratedMove, bool) {
+	stage := generator.stage
+	switch stage {
+	case CapturesStage:
+		generator.stage = QuietsStage
+	case QuietsStage, EvasionsStage:
+		generator.stage = DoneStage
+	default:
+		return DoneStage, nil, false
+	}
+	start := len(generator.buffer)
+	generator.buffer = generator.appendPseudoLegalMoves(generator.buffer, stage)
+	legal := generator.buffer[start:start]
+	for i := start; i < len(generator.buffer); i++ {
+		// a pointer into the buffer is passed, so the move isn't copied to the heap
+		if generator.IsLegal(&generator.buffer[i]) {
+			legal = append(legal, generator.buffer[i])
+		}
+	}
+	generator.buffer = generator.buffer[:start+len(legal)]
+	return stage, legal, true
+}
+
+// LegalMoves resets the generator to given position and returns moves of all its stages
+func (generator *StagedMoveGenerator) LegalMoves(position *Board) []GeneratedMove {
+	generator.Reset(position)
+	for {
+		if _, _, ok := generator.NextStage(); !ok {
+			return generator.buffer
+		}
+	}
+}
+
+// IsLegal checks whether pseudo-legal move of the side to move doesn't leave its king attacked.
+// Only king moves and en passant captures need the board to be looked at, other moves are checked by the pins
+// and the checkers of the position
+func (generator *StagedMoveGenerator) IsLegal(move MoveFields) bool {
+	position := generator.position
+	if generator.kingCords == nil {
+		return true
+	}
+	departure := move.Departure()
+	if departure.Figure.FigureType == King || isEnPassantMove(move) {
+		return !generator.view.isKingAttackedAfterMove(position, move)
+	}
+	if len(generator.checkers) > 1 {
+		return false
+	}
+	destinationCords := move.Destination().Cords
+	if pinDirection := generator.pinDirections[departure.Cords.Row][departure.Cords.Col]; pinDirection != (Direction{}) {
+		direction, aligned := DirectionBetween(*generator.kingCords, destinationCords)
+		if !aligned || direction != pinDirection {
+			return false
+		}
+	}
+	return len(generator.checkers) == 0 || generator.evasionTargets[destinationCords.Row][destinationCords.Col]
+}
+
+// appendPseudoLegalMoves appends moves of the side to move belonging to given stage. Moves may leave the king attacked
+func (generator *StagedMoveGenerator) appendPseudoLegalMoves(moves []GeneratedMove, stage GenerationStage) []GeneratedMove {
+	position := generator.position
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			field := position.board[row][col]
+			if !field.Filled || field.Figure.FigureSide != generator.side {
+				continue
+			}
+			if len(generator.checkers) > 1 && field.Figure.FigureType != King {
+				continue
+			}
+			switch field.Figure.FigureType {
+			case Pawn:
+				moves = generator.appendPawnMoves(moves, field, stage)
+			case Knight:
+				moves = generator.appendOffsetMoves(moves, field, knightOffsets, stage)
+			case King:
+				moves = generator.appendOffsetMoves(moves, field, kingOffsets, stage)
+				if stage == QuietsStage {
+					moves = generator.appendCastlingMoves(moves, field)
+				}
+			case Rook:
+				moves = generator.appendSliderMoves(moves, field, LineDirections, stage)
+			case Bishop:
+				moves = generator.appendSliderMoves(moves, field, DiagonalDirections, stage)
+			case Queen:
+				moves = generator.appendSliderMoves(moves, field, LineDirections, stage)
+				moves = generator.appendSliderMoves(moves, field, DiagonalDirections, stage)
+			}
+		}
+	}
+	return moves
+}
+
+func (generator *StagedMoveGenerator) appendOffsetMoves(moves []GeneratedMove, field Field, offsets []Cords, stage GenerationStage) []GeneratedMove {
+	for _, offset := range offsets {
+		cords := Cords{Col: field.Cords.Col + offset.Col, Row: field.Cords.Row + offset.Row}
+		if isOnBoard(cords) {
+			moves = generator.appendMove(moves, field, generator.position.GetField(cords), stage)
+		}
+	}
+	return moves
+}
+
+func (generator *StagedMoveGenerator) appendSliderMoves(moves []GeneratedMove, field Field, directions []Direction, stage GenerationStage) []GeneratedMove {
+	for _, direction := range directions {
+		for cords := direction.Next(field.Cords); isOnBoard(cords); cords = direction.Next(cords) {
+			destination := generator.position.GetField(cords)
+			moves = generator.appendMove(moves, field, destination, stage)
+			if destination.Filled {
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// appendMove appends move of a figure other than pawn if the destination is free or taken by the opponent
+// and the move belongs to the stage
+func (generator *StagedMoveGenerator) appendMove(moves []GeneratedMove, field Field, destination Field, stage GenerationStage) []GeneratedMove {
+	if destination.Filled && destination.Figure.FigureSide == generator.side {
+		return moves
+	}
+	if stage == EvasionsStage || destination.Filled == (stage == CapturesStage) {
+		moves = append(moves, GeneratedMove{departure: field, destination: destination})
+	}
+	return moves
+}
+
+func (generator *StagedMoveGenerator) appendPawnMoves(moves []GeneratedMove, field Field, stage GenerationStage) []GeneratedMove {
+	position := generator.position
+	rowDelta, initialRow, lastRow := 1, 1, ChessboardSize-1
+	if generator.side == Black {
+		rowDelta, initialRow, lastRow = -1, ChessboardSize-2, 0
+	}
+	destinations := make([]Field, 0, 4)
+	// a pawn left on its last row by a set up position has no moves
+	if forwardCords := (Cords{Col: field.Cords.Col, Row: field.Cords.Row + rowDelta}); isOnBoard(forwardCords) {
+		if forward := position.GetField(forwardCords); !forward.Filled {
+			destinations = append(destinations, forward)
+			doubleStepCords := Cords{Col: field.Cords.Col, Row: field.Cords.Row + 2*rowDelta}
+			if field.Cords.Row == initialRow && isOnBoard(doubleStepCords) {
+				if doubleStep := position.GetField(doubleStepCords); !doubleStep.Filled {
+					destinations = append(destinations, doubleStep)
+				}
+			}
+		}
+	}
+	enPassantCords := position.EnPassantCords()
+	for _, col := range [2]int{field.Cords.Col - 1, field.Cords.Col + 1} {
+		cords := Cords{Col: col, Row: field.Cords.Row + rowDelta}
+		if !isOnBoard(cords) {
+			continue
+		}
+		destination := position.GetField(cords)
+		if destination.Filled && destination.Figure.FigureSide != generator.side ||
+			enPassantCords != nil && *enPassantCords == cords {
+			destinations = append(destinations, destination)
+		}
+	}
+
+	for _, destination := range destinations {
+		isCapture := destination.Cords.Col != field.Cords.Col
+		isPromotion := destination.Cords.Row == lastRow
+		if stage == CapturesStage && !isCapture && !isPromotion || stage == QuietsStage && (isCapture || isPromotion) {
+			continue
+		}
+		if !isPromotion {
+			moves = append(moves, GeneratedMove{departure: field, destination: destination})
+			continue
+		}
+		for _, promoteToType := range promotionTypes {
+			moves = append(moves, GeneratedMove{departure: field, destination: destination, promoteToType: promoteToType})
+		}
+	}
+	return moves
+}
+
+func (generator *StagedMoveGenerator) appendCastlingMoves(moves []GeneratedMove, king Field) []GeneratedMove {
+	position := generator.position
+	rights := position.CastlingRights()
+	short, long := rights.WhiteShort, rights.WhiteLong
+	if generator.side == Black {
+		short, long = rights.BlackShort, rights.BlackLong
+	}
+	row := king.Cords.Row
+	for _, castling := range [2]struct {
+		allowed     bool
+		rookCol     int
+		destination int
+	}{{short, ChessboardSize - 1, 6}, {long, 0, 2}} {
+		if !castling.allowed || !position.IsPathClear(king.Cords, Cords{Col: castling.rookCol, Row: row}) {
+			continue
+		}
+		passed := Cords{Col: (king.Cords.Col + castling.destination) / 2, Row: row}
+		if position.IsFieldAttackedByOpposedSide(passed, generator.side) {
+			continue
+		}
+		moves = append(moves, GeneratedMove{departure: king, destination: position.GetField(Cords{Col: castling.destination, Row: row})})
+	}
+	return moves
+}
@@ -5,35 +5,40 @@ import (
 	"math"
 )
 
+// MoveValidator checks single rule of the move made in given position. It returns *ValidationError if the rule is broken
 type MoveValidator interface {
-	Validate(move Move) bool
+	Validate(position *Board, move Move) error
 }
 
-func InitValidators(actualBoard *Board) map[FigureType][]MoveValidator {
+func InitValidators() map[FigureType][]MoveValidator {
 	validators := make(map[FigureType][]MoveValidator, 6)
+	sideToMoveValidator := SideToMoveValidator{}
 	bordersBreachValidator := BordersBreachValidator{}
 	departureEqualsDestinationValidator := DepartureEqualsDestinationValidator{}
 	notAllyChessmanValidator := NotAllyChessmanValidator{}
-	kingIsNotAttackedAfterMoveValidator := KingIsNotAttackedAfterMoveValidator{actualBoard}
-	linePathValidator := LinePathValidator{actualBoard}
-	diagonalPathValidator := DiagonalPathValidator{actualBoard}
+	kingIsNotAttackedAfterMoveValidator := KingIsNotAttackedAfterMoveValidator{}
+	linePathValidator := LinePathValidator{}
+	diagonalPathValidator := DiagonalPathValidator{}
 	validators[King] = []MoveValidator{
+		sideToMoveValidator,
 		bordersBreachValidator,
 		departureEqualsDestinationValidator,
 		notAllyChessmanValidator,
 		kingIsNotAttackedAfterMoveValidator,
 		KingMoveValidator{},
-		CastlingMoveValidator{actualBoard},
+		CastlingMoveValidator{},
 	}
 	validators[Pawn] = []MoveValidator{
+		sideToMoveValidator,
 		bordersBreachValidator,
 		departureEqualsDestinationValidator,
 		notAllyChessmanValidator,
 		kingIsNotAttackedAfterMoveValidator,
-		PawnMoveValidator{actualBoard},
+		PawnMoveValidator{},
 		PromotionMoveValidator{},
 	}
 	validators[Rook] = []MoveValidator{
+		sideToMoveValidator,
 		bordersBreachValidator,
 		departureEqualsDestinationValidator,
 		notAllyChessmanValidator,
@@ -42,6 +47,7 @@ func InitValidators(actualBoard *Board) map[FigureType][]MoveValidator {
 		RookMoveValidator{},
 	}
 	validators[Knight] = []MoveValidator{
+		sideToMoveValidator,
 		bordersBreachValidator,
 		departureEqualsDestinationValidator,
 		notAllyChessmanValidator,
@@ -49,6 +55,7 @@ func InitValidators(actualBoard *Board) map[FigureType][]MoveValidator {
 		KnightMoveValidator{},
 	}
 	validators[Bishop] = []MoveValidator{
+		sideToMoveValidator,
 		bordersBreachValidator,
 		departureEqualsDestinationValidator,
 		notAllyChessmanValidator,
@@ -57,6 +64,7 @@ func InitValidators(actualBoard *Board) map[FigureType][]MoveValidator {
 		BishopMoveValidator{},
 	}
 	validators[Queen] = []MoveValidator{
+		sideToMoveValidator,
 		bordersBreachValidator,
 		departureEqualsDestinationValidator,
 		notAllyChessmanValidator,
@@ -68,103 +76,75 @@ func InitValidators(actualBoard *Board) map[FigureType][]MoveValidator {
 	return validators
 }
 
+type SideToMoveValidator struct{}
+
+func (SideToMoveValidator) Validate(position *Board, move Move) error {
+	if move.Departure().Figure.FigureSide != position.SideToMove() {
+		return newValidationError("SideToMoveValidator", WrongSideCode, move)
+	}
+	return nil
+}
+
 type BordersBreachValidator struct{}
 
-func (BordersBreachValidator) Validate(move Move) bool {
-	destinationCords := move.Destination().Cords
-	valid := destinationCords.Col >= 0 && destinationCords.Col < ChessboardSize &&
-		destinationCords.Row >= 0 && destinationCords.Row < ChessboardSize
-	return valid
+func (BordersBreachValidator) Validate(_ *Board, move Move) error {
+	if !move.Departure().Cords.IsOnBoard() || !move.Destination().Cords.IsOnBoard() {
+		return newValidationError("BordersBreachValidator", OutOfBordersCode, move)
+	}
+	return nil
 }
 
 type DepartureEqualsDestinationValidator struct{}
 
-func (DepartureEqualsDestinationValidator) Validate(move Move) bool {
-	return !move.Departure().Cords.Equal(move.Destination().Cords)
+func (DepartureEqualsDestinationValidator) Validate(_ *Board, move Move) error {
+	if move.Departure().Cords.Equal(move.Destination().Cords) {
+		return newValidationError("DepartureEqualsDestinationValidator", SameFieldCode, move)
+	}
+	return nil
 }
 
 type NotAllyChessmanValidator struct{}
 
-func (NotAllyChessmanValidator) Validate(move Move) bool {
-	if !move.Destination().Filled {
-		return true
+func (NotAllyChessmanValidator) Validate(_ *Board, move Move) error {
+	if move.Destination().Filled && move.Departure().Figure.FigureSide == move.Destination().Figure.FigureSide {
+		return newValidationError("NotAllyChessmanValidator", AllyChessmanCode, move)
 	}
-	return move.Departure().Figure.FigureSide != move.Destination().Figure.FigureSide
+	return nil
 }
 
-type LinePathValidator struct {
-	ActualBoard *Board
-}
+type LinePathValidator struct{}
 
-func (moveValidator LinePathValidator) Validate(move Move) bool {
-	startCol := move.Departure().Cords.Col
-	startRow := move.Departure().Cords.Row
-
-	destCol := move.Destination().Cords.Col
-	destRow := move.Destination().Cords.Row
-
-	if destRow != startRow && destCol != startCol {
-		return true
+func (LinePathValidator) Validate(position *Board, move Move) error {
+	departureCords := move.Departure().Cords
+	destinationCords := move.Destination().Cords
+	direction, aligned := DirectionBetween(departureCords, destinationCords)
+	if !aligned || direction.IsDiagonal() {
+		return nil
 	}
-
-	temp := startCol
-	startCol = min(startCol, destCol)
-	destCol = max(temp, destCol)
-
-	temp = startRow
-	startRow = min(startRow, destRow)
-	destRow = max(temp, destRow)
-
-	if destRow == startRow {
-		for col := startCol + 1; col < destCol; col++ {
-			if moveValidator.ActualBoard.GetField(Cords{Col: col, Row: startRow}).Filled {
-				return false
-			}
-		}
-	} else {
-		for row := startRow + 1; row < destRow; row++ {
-			if moveValidator.ActualBoard.GetField(Cords{Col: startCol, Row: row}).Filled {
-				return false
-			}
-		}
+	if !position.IsPathClear(departureCords, destinationCords) {
+		return newValidationError("LinePathValidator", PathBlockedCode, move)
 	}
-	return true
+	return nil
 }
 
-type DiagonalPathValidator struct {
-	ActualBoard *Board
-}
-
-func (moveValidator DiagonalPathValidator) Validate(move Move) bool {
-	startCol := move.Departure().Cords.Col
-	startRow := move.Departure().Cords.Row
+type DiagonalPathValidator struct{}
 
-	destCol := move.Destination().Cords.Col
-	destRow := move.Destination().Cords.Row
-
-	if math.Abs(float64(destRow-startRow)) != math.Abs(float64(destCol-startCol)) {
-		return true
+func (DiagonalPathValidator) Validate(position *Board, move Move) error {
+	departureCords := move.Departure().Cords
+	destinationCords := move.Destination().Cords
+	direction, aligned := DirectionBetween(departureCords, destinationCords)
+	if !aligned || !direction.IsDiagonal() {
+		return nil
 	}
-
-	if (destCol > startCol && destRow < startRow) || (destCol < startCol && destRow > startRow) {
-		for col := startCol; col < destCol; col++ {
-			if moveValidator.ActualBoard.GetField(Cords{Col: col, Row: ChessboardSize - col - 1}).Filled {
-				return false
-			}
-		}
-	} else {
-		for i := 0; i < destCol; i++ {
-			if moveValidator.ActualBoard.GetField(Cords{Col: i, Row: i}).Filled {
-				return false
-			}
-		}
+	if !position.IsPathClear(departureCords, destinationCords) {
+		return newValidationError("DiagonalPathValidator", PathBlockedCode, move)
 	}
-	return true
+	return nil
 }
 
 type KnightMoveValidator struct{}
 
-func (KnightMoveValidator) Validate(move Move) bool {
+func (KnightMoveValidator) Validate(_ *Board, move Move) error {
 	startCol := move.Departure().Cords.Col
 	startRow := move.Departure().Cords.Row
 
@@ -173,12 +153,15 @@ func (KnightMoveValidator) Validate(move Move) bool {
 
 	isValid := (math.Abs(float64(destCol-startCol)) == 2 && math.Abs(float64(destRow-startRow)) == 1) ||
 		(math.Abs(float64(destCol-startCol)) == 1 && math.Abs(float64(destRow-startRow)) == 2)
-	return isValid
+	if !isValid {
+		return newValidationError("KnightMoveValidator", IllegalFigureMoveCode, move)
+	}
+	return nil
 }
 
 type QueenMoveValidator struct{}
 
-func (QueenMoveValidator) Validate(move Move) bool {
+func (QueenMoveValidator) Validate(_ *Board, move Move) error {
 	startCol := move.Departure().Cords.Col
 	startRow := move.Departure().Cords.Row
 
@@ -186,19 +169,19 @@ func (QueenMoveValidator) Validate(move Move) bool {
 	destRow := move.Destination().Cords.Row
 
 	if destRow == startRow || destCol == startCol {
-		return true
+		return nil
 	}
 
 	if math.Abs(float64(destRow-startRow)) == math.Abs(float64(destCol-startCol)) {
-		return true
+		return nil
 	}
 
-	return false
+	return newValidationError("QueenMoveValidator", IllegalFigureMoveCode, move)
 }
 
 type RookMoveValidator struct{}
 
-func (RookMoveValidator) Validate(move Move) bool {
+func (RookMoveValidator) Validate(_ *Board, move Move) error {
 	startCol := move.Departure().Cords.Col
 	startRow := move.Departure().Cords.Row
 
@@ -206,12 +189,15 @@ func (RookMoveValidator) Validate(move Move) bool {
 	destRow := move.Destination().Cords.Row
 
 	isValid := destRow == startRow || destCol == startCol
-	return isValid
+	if !isValid {
+		return newValidationError("RookMoveValidator", IllegalFigureMoveCode, move)
+	}
+	return nil
 }
 
 type BishopMoveValidator struct{}
 
-func (BishopMoveValidator) Validate(move Move) bool {
+func (BishopMoveValidator) Validate(_ *Board, move Move) error {
 	startCol := move.Departure().Cords.Col
 	startRow := move.Departure().Cords.Row
 
@@ -219,14 +205,22 @@ func (BishopMoveValidator) Validate(move Move) bool {
 	destRow := move.Destination().Cords.Row
 
 	isValid := math.Abs(float64(destRow-startRow)) == math.Abs(float64(destCol-startCol))
-	return isValid
+	if !isValid {
+		return newValidationError("BishopMoveValidator", IllegalFigureMoveCode, move)
+	}
+	return nil
 }
 
-type PawnMoveValidator struct {
-	ActualBoard *Board
+type PawnMoveValidator struct{}
+
+func (moveValidator PawnMoveValidator) Validate(position *Board, move Move) error {
+	if !moveValidator.isValid(position, move) {
+		return newValidationError("PawnMoveValidator", IllegalFigureMoveCode, move)
+	}
+	return nil
 }
 
-func (moveValidator PawnMoveValidator) Validate(move Move) bool {
+func (moveValidator PawnMoveValidator) isValid(position *Board, move Move) bool {
 	startCol := move.Departure().Cords.Col
 	destCords := move.Destination().Cords
 	destCol := destCords.Col
@@ -239,9 +233,8 @@ func (moveValidator PawnMoveValidator) Validate(move Move) bool {
 		rowDistance = move.Departure().Cords.Row - destCords.Row
 	}
 
-	actualBoard := moveValidator.ActualBoard
 	if startCol == destCol {
-		destinationField := actualBoard.GetField(destCords)
+		destinationField := position.GetField(destCords)
 		if rowDistance == 1 {
 			return !destinationField.Filled
 		} else if rowDistance == 2 {
@@ -251,7 +244,7 @@ func (moveValidator PawnMoveValidator) Validate(move Move) bool {
 			} else {
 				diff = -1
 			}
-			passField := actualBoard.GetField(Cords{Col: destCol, Row: destCords.Row - diff})
+			passField := position.GetField(Cords{Col: destCol, Row: destCords.Row - diff})
 			return !movingPawn.Moved && !passField.Filled && !destinationField.Filled
 		} else {
 			return false
@@ -264,7 +257,7 @@ func (moveValidator PawnMoveValidator) Validate(move Move) bool {
 		if move.Destination().Filled {
 			return movingPawn.FigureSide != move.Destination().Figure.FigureSide
 		} else {
-			lastMove := actualBoard.GetLastMove()
+			lastMove := position.GetLastMove()
 			if lastMove == nil {
 				return false
 			}
@@ -279,29 +272,45 @@ var promotionAllowedTypes = mapset.NewSet(Queen, Rook, Bishop, Knight)
 
 type PromotionMoveValidator struct{}
 
-func (PromotionMoveValidator) Validate(move Move) bool {
-	if promotionMove, isPromotionMove := move.(PromotionMove); isPromotionMove {
-		return promotionAllowedTypes.Contains(promotionMove.promoteToType)
-	} else {
-		return true
+func (PromotionMoveValidator) Validate(_ *Board, move Move) error {
+	promotionMove, isPromotionMove := move.(PromotionMove)
+	if !isPromotionMove {
+		return nil
 	}
+	if promotionMove.promoteToType == EmptyType {
+		return newValidationError("PromotionMoveValidator", PromotionTypeMissingCode, move)
+	}
+	if !promotionAllowedTypes.Contains(promotionMove.promoteToType) {
+		return newValidationError("PromotionMoveValidator", PromotionTypeInvalidCode, move)
+	}
+	return nil
 }
 
 type KingMoveValidator struct{}
 
-func (KingMoveValidator) Validate(move Move) bool {
+func (KingMoveValidator) Validate(_ *Board, move Move) error {
 	colDiff := math.Abs(float64(move.Departure().Cords.Col - move.Destination().Cords.Col))
 	rowDiff := math.Abs(float64(move.Departure().Cords.Row - move.Destination().Cords.Row))
-	return colDiff <= 1 && rowDiff <= 1 || colDiff == 2 && rowDiff == 0
+	if colDiff <= 1 && rowDiff <= 1 || colDiff == 2 && rowDiff == 0 {
+		return nil
+	}
+	return newValidationError("KingMoveValidator", IllegalFigureMoveCode, move)
 }
 
-type CastlingMoveValidator struct {
-	ActualBoard *Board
+type CastlingMoveValidator struct{}
+
+func (moveValidator CastlingMoveValidator) Validate(position *Board, move Move) error {
+	if _, isCastleMove := move.(CastleMove); !isCastleMove {
+		return nil
+	}
+	if !moveValidator.isValid(position, move) {
+		return newValidationError("CastlingMoveValidator", CastlingNotAllowedCode, move)
+	}
+	return nil
 }
 
-func (moveValidator CastlingMoveValidator) Validate(move Move) bool {
+func (moveValidator CastlingMoveValidator) isValid(position *Board, move Move) bool {
 	king := move.Departure().Figure
-	board := moveValidator.ActualBoard
 	if king.Moved {
 		return false
 	}
@@ -317,21 +326,21 @@ func (moveValidator CastlingMoveValidator) Validate(move Move) bool {
 
 	// if castle side rook isn't a rook or moved before, then can't castle
 	c := Cords{Col: rookCol, Row: row}
-	if rook := board.GetField(c).Figure; rook.FigureType != Rook || rook.Moved || rook.FigureSide != king.FigureSide {
+	if rook := position.GetField(c).Figure; rook.FigureType != Rook || rook.Moved || rook.FigureSide != king.FigureSide {
 		return false
 	}
 
 	// if any field between the king and the rook are filled then can't castle
 	kingCol := move.Departure().Cords.Col
 	for col := min(kingCol, rookCol) + 1; col < max(kingCol, rookCol); col++ {
-		if board.GetField(Cords{Col: col, Row: row}).Filled {
+		if position.GetField(Cords{Col: col, Row: row}).Filled {
 			return false
 		}
 	}
 
 	// if any field between the king and the destination are attacked then can't castle
 	for col := min(move.Destination().Cords.Col, kingCol); col <= max(move.Destination().Cords.Col, kingCol); col++ {
-		if board.IsFieldAttackedByOpposedSide(Cords{Col: col, Row: row}, king.FigureSide) {
+		if position.IsFieldAttackedByOpposedSide(Cords{Col: col, Row: row}, king.FigureSide) {
 			return false
 		}
 	}
@@ -339,28 +348,11 @@ func (moveValidator CastlingMoveValidator) Validate(move Move) bool {
 	return true
 }
 
-type KingIsNotAttackedAfterMoveValidator struct {
-	ActualBoard *Board
-}
+type KingIsNotAttackedAfterMoveValidator struct{}
 
-func (moveValidator KingIsNotAttackedAfterMoveValidator) Validate(move Move) bool {
-	departure := move.Departure()
-	movingFigure := departure.Figure
-	if moveValidator.ActualBoard.GetKingCords(movingFigure.FigureSide) == nil {
-		return true
+func (KingIsNotAttackedAfterMoveValidator) Validate(position *Board, move Move) error {
+	if position.isKingAttackedAfterMove(move) {
+		return newValidationError("KingIsNotAttackedAfterMoveValidator", KingAttackedCode, move)
 	}
-	validationBoard := moveValidator.ActualBoard.Copy()
-	departure.Figure = Figure{}
-	departure.Filled = false
-	destination := move.Destination()
-	destination.Figure = movingFigure
-	destination.Filled = true
-
-	validationBoard.SetField(departure)
-	validationBoard.SetField(destination)
-
-	movingFigureSide := movingFigure.FigureSide
-	kingCords := validationBoard.GetKingCords(movingFigureSide)
-
-	return !validationBoard.IsFieldAttackedByOpposedSide(*kingCords, movingFigureSide)
+	return nil
 }
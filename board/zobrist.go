@@ -0,0 +1,135 @@
+package board
+
+// Zobrist keys: a position hash is XOR of keys of every figure on its field, the side to move,
+// every castling right and the file of the pawn which can be captured en passant
+var (
+	figureKeys    [3][7][ChessboardSize * ChessboardSize]uint64
+	blackToMove   uint64
+	castlingKeys  [4]uint64
+	enPassantKeys [ChessboardSize]uint64
+)
+
+// zobristSeed makes hashes the same on every start, so they can be stored
+const zobristSeed = 0x2d358dccaa6c78a5
+
+func init() {
+	state := uint64(zobristSeed)
+	next := func() uint64 {
+		// xorshift64*
+		state ^= state >> 12
+		state ^= state << 25
+		state ^= state >> 27
+		return state * 0x2545f4914f6cdd1d
+	}
+	for _, side := range []FigureSide{White, Black} {
+		for figureType := King; figureType <= Queen; figureType++ {
+			for i := range figureKeys[side][figureType] {
+				figureKeys[side][figureType][i] = next()
+			}
+		}
+	}
+	blackToMove = next()
+	for i := range castlingKeys {
+		castlingKeys[i] = next()
+	}
+	for i := range enPassantKeys {
+		enPassantKeys[i] = next()
+	}
+}
+
+func fieldKey(field Field) uint64 {
+	if !field.Filled {
+		return 0
+	}
+	return figureKeys[field.Figure.FigureSide][field.Figure.FigureType][field.Cords.Row*ChessboardSize+field.Cords.Col]
+}
+
+// Hash returns 64-bit Zobrist hash of the position. Positions considered the same by SamePosition have equal hashes.
+// Both the figures and the rest of the state are hashed incrementally as the board changes
+func (board *Board) Hash() uint64 {
+	return board.figuresHash ^ board.stateHash
+}
+
+// ComputeHash calculates the same hash as Hash from scratch. Castling rights and the en passant file are derived
+// from the fields and the last move directly rather than by the accessors the incremental hash uses, so the two
+// check each other
+func (board *Board) ComputeHash() uint64 {
+	var hash uint64
+	for row := 0; row < ChessboardSize; row++ {
+		for col := 0; col < ChessboardSize; col++ {
+			hash ^= fieldKey(board.board[row][col])
+		}
+	}
+	if board.sideToMove == Black {
+		hash ^= blackToMove
+	}
+	unmoved := func(col int, row int, figureType FigureType, side FigureSide) bool {
+		field := board.board[row][col]
+		return field.Filled && !field.Figure.Moved && field.Figure.FigureType == figureType && field.Figure.FigureSide == side
+	}
+	for i, castling := range [4]struct {
+		side    FigureSide
+		row     int
+		rookCol int
+	}{{White, 0, 7}, {White, 0, 0}, {Black, 7, 7}, {Black, 7, 0}} {
+		if unmoved(4, castling.row, King, castling.side) && unmoved(castling.rookCol, castling.row, Rook, castling.side) {
+			hash ^= castlingKeys[i]
+		}
+	}
+	if lastMove := board.lastMove; lastMove != nil && lastMove.Departure().Figure.FigureType == Pawn {
+		departure, destination := lastMove.Departure().Cords, lastMove.Destination().Cords
+		if departure.Col == destination.Col && (destination.Row-departure.Row == 2 || departure.Row-destination.Row == 2) {
+			for _, col := range []int{destination.Col - 1, destination.Col + 1} {
+				if col < 0 || col >= ChessboardSize {
+					continue
+				}
+				if pawn := board.board[destination.Row][col]; pawn.Filled && pawn.Figure.FigureType == Pawn && pawn.Figure.FigureSide == board.sideToMove {
+					hash ^= enPassantKeys[destination.Col]
+					break
+				}
+			}
+		}
+	}
+	return hash
+}
+
+// castlingHash returns keys of castling rights of given side
+func (board *Board) castlingHash(side FigureSide) uint64 {
+	shortKey, longKey := castlingKeys[0], castlingKeys[1]
+	if side == Black {
+		shortKey, longKey = castlingKeys[2], castlingKeys[3]
+	}
+	var hash uint64
+	if board.hasCastlingRight(side, ChessboardSize-1) {
+		hash ^= shortKey
+	}
+	if board.hasCastlingRight(side, 0) {
+		hash ^= longKey
+	}
+	return hash
+}
+
+// enPassantHash returns key of the file of the pawn which can be captured en passant, or 0 if there is none
+func (board *Board) enPassantHash() uint64 {
+	if enPassantCords, ok := board.capturableEnPassantCords(); ok {
+		return enPassantKeys[enPassantCords.Col]
+	}
+	return 0
+}
+
+// fieldStateHash returns keys of the state which depends on the field at given cords: castling rights if it's
+// an initial field of a king or a rook, and the en passant file if it's on the row of the pawn which made a double step
+func (board *Board) fieldStateHash(cords Cords) uint64 {
+	var hash uint64
+	if (cords.Row == 0 || cords.Row == ChessboardSize-1) && (cords.Col == 0 || cords.Col == 4 || cords.Col == ChessboardSize-1) {
+		side := White
+		if cords.Row != GetDefaultRowBySide(White) {
+			side = Black
+		}
+		hash ^= board.castlingHash(side)
+	}
+	if board.lastMove != nil && board.lastMove.Destination().Cords.Row == cords.Row {
+		hash ^= board.enPassantHash()
+	}
+	return hash
+}
@@ -0,0 +1,152 @@
+package clock
+
+import (
+	"chess/board"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoStages     = errors.New("time control has no stages")
+	ErrNegativeTime = errors.New("time control has negative time")
+)
+
+// TimeSource gives current time. It is replaced in tests to control time flow
+type TimeSource interface {
+	Now() time.Time
+}
+
+type SystemTimeSource struct{}
+
+func (SystemTimeSource) Now() time.Time {
+	return time.Now()
+}
+
+type sideClock struct {
+	remaining time.Duration
+	movesMade int
+	stage     int
+}
+
+// Clock counts time of both sides. Only the side to move has its clock running
+type Clock struct {
+	control     TimeControl
+	timeSource  TimeSource
+	sides       map[board.FigureSide]*sideClock
+	running     board.FigureSide
+	turnStarted time.Time
+}
+
+// MakeClock returns stopped clock for given time control. It returns ErrNoStages or ErrNegativeTime
+// if the control can't be played
+func MakeClock(control TimeControl, timeSource TimeSource) (*Clock, error) {
+	if len(control.Stages) == 0 {
+		return nil, ErrNoStages
+	}
+	for _, stage := range control.Stages {
+		if stage.Time < 0 || stage.Increment < 0 || stage.Delay < 0 {
+			return nil, ErrNegativeTime
+		}
+	}
+	initialTime := control.Stages[0].Time
+	return &Clock{
+		control:    control,
+		timeSource: timeSource,
+		sides: map[board.FigureSide]*sideClock{
+			board.White: {remaining: initialTime},
+			board.Black: {remaining: initialTime},
+		},
+		running: board.EmptySide,
+	}, nil
+}
+
+// Start runs the clock of given side
+func (clock *Clock) Start(side board.FigureSide) {
+	clock.running = side
+	clock.turnStarted = clock.timeSource.Now()
+}
+
+// Stop stops the running clock keeping the time used so far
+func (clock *Clock) Stop() {
+	if clock.running == board.EmptySide {
+		return
+	}
+	sideClock := clock.sides[clock.running]
+	sideClock.remaining = clock.Remaining(clock.running)
+	clock.running = board.EmptySide
+}
+
+// Running returns side whose clock is running, or board.EmptySide if the clock is stopped
+func (clock *Clock) Running() board.FigureSide {
+	return clock.running
+}
+
+// Press finishes the move of given side, adds increment or delay compensation and starts the clock of the opponent.
+// It returns true if the side ran out of time before the move was finished, in which case the clock stops
+func (clock *Clock) Press(side board.FigureSide) bool {
+	if clock.running != side {
+		return false
+	}
+	sideClock := clock.sides[side]
+	stage := clock.control.Stages[sideClock.stage]
+	elapsed := clock.timeSource.Now().Sub(clock.turnStarted)
+	sideClock.remaining -= charged(stage, elapsed)
+	if sideClock.remaining <= 0 {
+		sideClock.remaining = 0
+		clock.running = board.EmptySide
+		return true
+	}
+
+	sideClock.remaining += stage.Increment
+	if stage.DelayType == Bronstein {
+		sideClock.remaining += min(elapsed, stage.Delay)
+	}
+	sideClock.movesMade++
+	if nextStage, starts := clock.control.stageAt(sideClock.movesMade); starts {
+		sideClock.stage = nextStage
+		sideClock.remaining += clock.control.Stages[nextStage].Time
+	}
+
+	clock.Start(side.Opposite())
+	return false
+}
+
+// Remaining returns time left for given side including time used for the current move
+func (clock *Clock) Remaining(side board.FigureSide) time.Duration {
+	sideClock := clock.sides[side]
+	if sideClock == nil {
+		return 0
+	}
+	if clock.running != side {
+		return sideClock.remaining
+	}
+	stage := clock.control.Stages[sideClock.stage]
+	remaining := sideClock.remaining - charged(stage, clock.timeSource.Now().Sub(clock.turnStarted))
+	return max(remaining, 0)
+}
+
+// Flagged returns side which has run out of time
+func (clock *Clock) Flagged() (board.FigureSide, bool) {
+	for _, side := range []board.FigureSide{board.White, board.Black} {
+		if clock.Remaining(side) <= 0 {
+			return side, true
+		}
+	}
+	return board.EmptySide, false
+}
+
+// MovesMade returns number of moves finished by given side
+func (clock *Clock) MovesMade(side board.FigureSide) int {
+	if sideClock := clock.sides[side]; sideClock != nil {
+		return sideClock.movesMade
+	}
+	return 0
+}
+
+// charged returns time taken from the clock for the move lasted elapsed time
+func charged(stage Stage, elapsed time.Duration) time.Duration {
+	if stage.DelayType == USDelay {
+		return max(elapsed-stage.Delay, 0)
+	}
+	return elapsed
+}
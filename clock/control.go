@@ -0,0 +1,61 @@
+package clock
+
+import "time"
+
+type DelayType int
+
+const (
+	NoDelay   DelayType = iota
+	Bronstein DelayType = iota
+	USDelay   DelayType = iota
+)
+
+// Stage is a part of time control. Time is added to the clock when the stage starts, and the stage lasts
+// for given number of moves. Zero moves means the stage lasts until the end of the game. If the last stage
+// lasts for given number of moves, it starts again every time the moves are made, as in 40 moves in 2 hours
+// repeating
+type Stage struct {
+	Moves     int
+	Time      time.Duration
+	Increment time.Duration
+	Delay     time.Duration
+	DelayType DelayType
+}
+
+type TimeControl struct {
+	Stages []Stage
+}
+
+// SuddenDeath returns control where all moves have to be made in given time
+func SuddenDeath(base time.Duration) TimeControl {
+	return TimeControl{Stages: []Stage{{Time: base}}}
+}
+
+// Fischer returns control adding increment after every move
+func Fischer(base time.Duration, increment time.Duration) TimeControl {
+	return TimeControl{Stages: []Stage{{Time: base, Increment: increment}}}
+}
+
+// BronsteinDelay returns control giving back the time used for the move, but not more than delay
+func BronsteinDelay(base time.Duration, delay time.Duration) TimeControl {
+	return TimeControl{Stages: []Stage{{Time: base, Delay: delay, DelayType: Bronstein}}}
+}
+
+// SimpleDelay returns control where the clock starts counting down only after delay passed since the move started
+func SimpleDelay(base time.Duration, delay time.Duration) TimeControl {
+	return TimeControl{Stages: []Stage{{Time: base, Delay: delay, DelayType: USDelay}}}
+}
+
+// stageAt returns index of the stage to play after given number of moves made and whether the stage starts
+// with that move. A final stage lasting for given number of moves is repeated until the end of the game
+func (control TimeControl) stageAt(movesMade int) (int, bool) {
+	moves := 0
+	for i, stage := range control.Stages {
+		if stage.Moves == 0 || movesMade < moves+stage.Moves {
+			return i, movesMade == moves
+		}
+		moves += stage.Moves
+	}
+	last := len(control.Stages) - 1
+	return last, (movesMade-moves)%control.Stages[last].Moves == 0
+}
@@ -0,0 +1,159 @@
+// Command perft verifies the move generator by counting positions reachable from the reference positions
+// and comparing the counts with the known ones. On a mismatch it descends along the first move whose count differs
+// from the bitboard generator, and reports the line leading to the diverging position.
+//
+// Usage:
+//
+//	perft [-depth 4] [-fen "<position>"] [-divide]
+package main
+
+import (
+	"chess/bitboard"
+	"chess/board"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+type referencePosition struct {
+	name  string
+	fen   string
+	nodes []int
+}
+
+var referencePositions = []referencePosition{
+	{"initial", board.DefaultFEN, []int{20, 400, 8902, 197281, 4865609}},
+	{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039, 97862, 4085603}},
+	{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812, 43238, 674624}},
+	{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{6, 264, 9467, 422333}},
+	{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{44, 1486, 62379, 2103487}},
+	{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []int{46, 2079, 89890, 3894594}},
+}
+
+func main() {
+	depth := flag.Int("depth", 3, "maximal depth to count")
+	fen := flag.String("fen", "", "count the given position instead of the reference ones")
+	divide := flag.Bool("divide", false, "print counts per first move")
+	flag.Parse()
+
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	if *fen != "" {
+		chessBoard, err := board.ParseFEN(*fen)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		run(generator, chessBoard, *depth, -1, *divide)
+		return
+	}
+
+	failed := false
+	for _, reference := range referencePositions {
+		fmt.Println(reference.name + ": " + reference.fen)
+		chessBoard, err := board.ParseFEN(reference.fen)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		for d := 1; d <= min(*depth, len(reference.nodes)); d++ {
+			if !run(generator, chessBoard, d, reference.nodes[d-1], *divide && d == *depth) {
+				failed = true
+				break
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run counts positions at given depth and compares the count with the expected one, if it's known
+func run(generator board.MoveGenerator, chessBoard *board.Board, depth int, expected int, divide bool) bool {
+	started := time.Now()
+	var nodes int
+	if divide {
+		for _, result := range generator.Divide(chessBoard, depth) {
+			fmt.Printf("  %s: %d\n", result.Move, result.Nodes)
+			nodes += result.Nodes
+		}
+	} else {
+		nodes = generator.Perft(chessBoard, depth)
+	}
+	elapsed := time.Since(started)
+
+	if expected < 0 {
+		fmt.Printf("  depth %d: %d nodes in %v\n", depth, nodes, elapsed)
+		return true
+	}
+	if nodes == expected {
+		fmt.Printf("  depth %d: %d nodes in %v, ok\n", depth, nodes, elapsed)
+		return true
+	}
+	fmt.Printf("  depth %d: %d nodes in %v, expected %d\n", depth, nodes, elapsed, expected)
+	reportDivergence(generator, chessBoard, depth)
+	return false
+}
+
+// reportDivergence compares per move counts with the bitboard generator and descends along the first differing move
+// until the position where the generators disagree on the legal moves themselves
+func reportDivergence(generator board.MoveGenerator, chessBoard *board.Board, depth int) {
+	line := make([]string, 0, depth)
+	for ; depth > 0; depth-- {
+		counts := make(map[string]int)
+		moves := make(map[string]board.Move)
+		for _, result := range generator.Divide(chessBoard, depth) {
+			counts[result.Move.String()] = result.Nodes
+			moves[result.Move.String()] = result.Move
+		}
+		position := bitboard.FromBoard(chessBoard)
+		referenceCounts := make(map[string]int)
+		for _, result := range position.Divide(depth) {
+			referenceCounts[result.Move.String()] = result.Nodes
+		}
+
+		missing, extra := difference(referenceCounts, counts), difference(counts, referenceCounts)
+		if len(missing) > 0 || len(extra) > 0 {
+			fmt.Printf("  diverging position after [%s]: %s\n", strings.Join(line, " "), chessBoard.FEN())
+			fmt.Printf("  missing moves: %v, illegal moves: %v\n", missing, extra)
+			return
+		}
+		diverging := ""
+		for _, notation := range sortedKeys(counts) {
+			if counts[notation] != referenceCounts[notation] {
+				diverging = notation
+				break
+			}
+		}
+		if diverging == "" {
+			fmt.Println("  bitboard generator agrees with the board, expected count may be wrong")
+			return
+		}
+		fmt.Printf("  first diverging move %s: %d nodes, bitboard counts %d\n",
+			diverging, counts[diverging], referenceCounts[diverging])
+		line = append(line, diverging)
+		chessBoard.MakeMove(moves[diverging])
+	}
+}
+
+// difference returns sorted moves present in the first counts but not in the second ones
+func difference(first map[string]int, second map[string]int) []string {
+	moves := make([]string, 0)
+	for _, notation := range sortedKeys(first) {
+		if _, present := second[notation]; !present {
+			moves = append(moves, notation)
+		}
+	}
+	return moves
+}
+
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
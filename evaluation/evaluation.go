@@ -0,0 +1,326 @@
+package evaluation
+
+import (
+	"chess/board"
+	"fmt"
+	"math/bits"
+)
+
+// Term is a part of the evaluation which can be looked at separately in the trace
+type Term int
+
+const (
+	MaterialTerm      Term = iota
+	PieceSquareTerm   Term = iota
+	PawnStructureTerm Term = iota
+	KingSafetyTerm    Term = iota
+	MobilityTerm      Term = iota
+	BishopPairTerm    Term = iota
+	termsCount        Term = iota
+)
+
+var termNames = map[Term]string{
+	MaterialTerm:      "material",
+	PieceSquareTerm:   "piece-square tables",
+	PawnStructureTerm: "pawn structure",
+	KingSafetyTerm:    "king safety",
+	MobilityTerm:      "mobility",
+	BishopPairTerm:    "bishop pair",
+}
+
+func (term Term) String() string {
+	if name, ok := termNames[term]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown term %d", int(term))
+}
+
+// Terms returns all the terms in the order they are evaluated
+func Terms() []Term {
+	terms := make([]Term, 0, termsCount)
+	for term := MaterialTerm; term < termsCount; term++ {
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// TermTrace shows the scores of both sides for a term. Value is the difference of the scores blended by the phase,
+// from the point of view of the side to move
+type TermTrace struct {
+	White Score
+	Black Score
+	Value int
+}
+
+// Trace breaks the evaluation down by terms. Score is the sum of the values of all the terms
+type Trace struct {
+	Phase    int
+	MaxPhase int
+	Terms    [termsCount]TermTrace
+	Score    int
+}
+
+// Evaluator scores positions with given weights
+type Evaluator struct {
+	Weights Weights
+}
+
+func MakeEvaluator(weights Weights) *Evaluator {
+	return &Evaluator{Weights: weights}
+}
+
+var defaultEvaluator = MakeEvaluator(DefaultWeights())
+
+// Evaluate scores the position with the default weights
+func Evaluate(position *board.Board) int {
+	return defaultEvaluator.Evaluate(position)
+}
+
+// Evaluate scores the position in centipawns from the point of view of the side to move. It counts the attacks
+// directly instead of building the attack map, so it doesn't allocate
+func (evaluator *Evaluator) Evaluate(position *board.Board) int {
+	var attacks attackCounts
+	attacks.count(position)
+	return evaluator.evaluate(position, &attacks, nil)
+}
+
+// Trace evaluates the position keeping the score of every term of both sides
+func (evaluator *Evaluator) Trace(position *board.Board) Trace {
+	attackMap := position.AttackMap()
+	var attacks attackCounts
+	attacks.fromAttackMap(position, &attackMap)
+	trace := Trace{}
+	evaluator.evaluate(position, &attacks, &trace)
+	return trace
+}
+
+// evaluate returns the score from the point of view of the side to move and fills the trace if it is given
+func (evaluator *Evaluator) evaluate(position *board.Board, attacks *attackCounts, trace *Trace) int {
+	weights := &evaluator.Weights
+	var scores [termsCount][board.Black + 1]Score
+	var pawnRows [board.Black + 1][board.ChessboardSize]uint8
+	var bishops [board.Black + 1]int
+	var kings [board.Black + 1]board.Cords
+	var hasKing [board.Black + 1]bool
+	phase, maxPhase := 0, weights.maxPhase()
+
+	for row := 0; row < board.ChessboardSize; row++ {
+		for col := 0; col < board.ChessboardSize; col++ {
+			field := position.GetField(board.Cords{Col: col, Row: row})
+			if !field.Filled {
+				continue
+			}
+			figureType, side := field.Figure.FigureType, field.Figure.FigureSide
+			scores[MaterialTerm][side] = scores[MaterialTerm][side].add(weights.Material[figureType])
+			scores[PieceSquareTerm][side] = scores[PieceSquareTerm][side].add(weights.PieceSquare[figureType][tableIndex(field.Cords, side)])
+			phase += weights.Phase[figureType]
+			switch figureType {
+			case board.Pawn:
+				pawnRows[side][col] |= 1 << row
+			case board.Bishop:
+				bishops[side]++
+			case board.King:
+				kings[side], hasKing[side] = field.Cords, true
+			}
+		}
+	}
+	phase = min(phase, maxPhase)
+
+	for side := board.White; side <= board.Black; side++ {
+		scores[PawnStructureTerm][side] = weights.pawnStructure(pawnRows[side], pawnRows[side.Opposite()], side)
+		if hasKing[side] {
+			scores[KingSafetyTerm][side] = weights.kingSafety(attacks, kings[side], pawnRows[side], side)
+		}
+		scores[MobilityTerm][side] = weights.mobility(attacks, side)
+		if bishops[side] >= 2 {
+			scores[BishopPairTerm][side] = weights.BishopPair
+		}
+	}
+
+	score := 0
+	for term := MaterialTerm; term < termsCount; term++ {
+		value := taper(scores[term][board.White], phase, maxPhase) - taper(scores[term][board.Black], phase, maxPhase)
+		if position.SideToMove() == board.Black {
+			value = -value
+		}
+		score += value
+		if trace != nil {
+			trace.Terms[term] = TermTrace{White: scores[term][board.White], Black: scores[term][board.Black], Value: value}
+		}
+	}
+	if trace != nil {
+		trace.Phase, trace.MaxPhase, trace.Score = phase, maxPhase, score
+	}
+	return score
+}
+
+// attackCounts keeps what the evaluation needs to know about the attacks: number of attackers of every field,
+// fields attacked by pawns as bit masks indexed by row*8+col, and mobility of every figure type
+type attackCounts struct {
+	attackers   [board.Black + 1][board.ChessboardSize][board.ChessboardSize]uint8
+	pawnAttacks [board.Black + 1]uint64
+	mobility    [board.Black + 1][board.Queen + 1]int
+}
+
+// count visits the attacks of all figures. Pawns go first, since mobility leaves out fields attacked by them
+func (attacks *attackCounts) count(position *board.Board) {
+	for _, pawns := range []bool{true, false} {
+		for row := 0; row < board.ChessboardSize; row++ {
+			for col := 0; col < board.ChessboardSize; col++ {
+				field := position.GetField(board.Cords{Col: col, Row: row})
+				if !field.Filled || (field.Figure.FigureType == board.Pawn) != pawns {
+					continue
+				}
+				side, figureType := field.Figure.FigureSide, field.Figure.FigureType
+				moves := 0
+				position.VisitAttacked(field, func(cords board.Cords) {
+					attacks.attackers[side][cords.Row][cords.Col]++
+					if figureType == board.Pawn {
+						attacks.pawnAttacks[side] |= squareBit(cords)
+					} else if attacks.isMobilityField(position, cords, side) {
+						moves++
+					}
+				})
+				attacks.addMobility(side, figureType, moves)
+			}
+		}
+	}
+}
+
+// fromAttackMap takes the counts from the attack map built for the trace
+func (attacks *attackCounts) fromAttackMap(position *board.Board, attackMap *board.AttackMap) {
+	for side := board.White; side <= board.Black; side++ {
+		for row := 0; row < board.ChessboardSize; row++ {
+			for col := 0; col < board.ChessboardSize; col++ {
+				square := attackMap.Square(board.Cords{Col: col, Row: row}, side)
+				attacks.attackers[side][row][col] = uint8(square.Count())
+				if square.ByType[board.Pawn] > 0 {
+					attacks.pawnAttacks[side] |= squareBit(board.Cords{Col: col, Row: row})
+				}
+			}
+		}
+	}
+	for side := board.White; side <= board.Black; side++ {
+		for _, piece := range attackMap.Pieces(side) {
+			moves := 0
+			for _, cords := range piece.Attacked {
+				if attacks.isMobilityField(position, cords, side) {
+					moves++
+				}
+			}
+			attacks.addMobility(side, piece.Field.Figure.FigureType, moves)
+		}
+	}
+}
+
+// isMobilityField reports whether the field is neither taken by an own figure nor attacked by opponent pawns
+func (attacks *attackCounts) isMobilityField(position *board.Board, cords board.Cords, side board.FigureSide) bool {
+	field := position.GetField(cords)
+	return (!field.Filled || field.Figure.FigureSide != side) && attacks.pawnAttacks[side.Opposite()]&squareBit(cords) == 0
+}
+
+func (attacks *attackCounts) addMobility(side board.FigureSide, figureType board.FigureType, moves int) {
+	if figureType != board.Pawn && figureType != board.King {
+		attacks.mobility[side][figureType] += moves
+	}
+}
+
+func squareBit(cords board.Cords) uint64 {
+	return 1 << (cords.Row*board.ChessboardSize + cords.Col)
+}
+
+// pawnStructure scores doubled, isolated and passed pawns. Rows of the pawns are kept as bit masks per column
+func (weights *Weights) pawnStructure(own [board.ChessboardSize]uint8, opponent [board.ChessboardSize]uint8, side board.FigureSide) Score {
+	var score Score
+	for col, rows := range own {
+		if rows == 0 {
+			continue
+		}
+		count := bits.OnesCount8(rows)
+		score = score.add(weights.DoubledPawn.times(count - 1))
+		if adjacentRows(own, col) == 0 {
+			score = score.add(weights.IsolatedPawn.times(count))
+		}
+		blockers := adjacentRows(opponent, col) | opponent[col]
+		for row := 0; row < board.ChessboardSize; row++ {
+			if rows&(1<<row) == 0 {
+				continue
+			}
+			ahead := blockers >> (row + 1)
+			relativeRow := row
+			if side == board.Black {
+				ahead = blockers & (1<<row - 1)
+				relativeRow = board.ChessboardSize - 1 - row
+			}
+			if ahead == 0 {
+				score = score.add(weights.PassedPawn[relativeRow])
+			}
+		}
+	}
+	return score
+}
+
+// kingSafety scores own pawns sheltering the king and attacks of the opponent around it
+func (weights *Weights) kingSafety(attacks *attackCounts, king board.Cords, pawnRows [board.ChessboardSize]uint8, side board.FigureSide) Score {
+	var score Score
+	direction := 1
+	if side == board.Black {
+		direction = -1
+	}
+	for col := king.Col - 1; col <= king.Col+1; col++ {
+		for distance := 1; distance <= 2; distance++ {
+			row := king.Row + distance*direction
+			if isOnBoard(col, row) && pawnRows[col]&(1<<row) != 0 {
+				score = score.add(weights.KingShield)
+			}
+		}
+		for row := king.Row - 1; row <= king.Row+1; row++ {
+			if isOnBoard(col, row) {
+				score = score.add(weights.KingZoneAttack.times(int(attacks.attackers[side.Opposite()][row][col])))
+			}
+		}
+	}
+	return score
+}
+
+// mobility scores fields attacked by the figures other than pawns and the king,
+// leaving out fields taken by own figures and fields attacked by opponent pawns
+func (weights *Weights) mobility(attacks *attackCounts, side board.FigureSide) Score {
+	var score Score
+	for figureType, moves := range attacks.mobility[side] {
+		score = score.add(weights.Mobility[figureType].times(moves))
+	}
+	return score
+}
+
+// taper blends middlegame and endgame values by the phase
+func taper(score Score, phase int, maxPhase int) int {
+	if maxPhase == 0 {
+		return score.Endgame
+	}
+	return (score.Middlegame*phase + score.Endgame*(maxPhase-phase)) / maxPhase
+}
+
+// tableIndex returns index in the piece-square tables, which start at a8 for white
+func tableIndex(cords board.Cords, side board.FigureSide) int {
+	row := board.ChessboardSize - 1 - cords.Row
+	if side == board.Black {
+		row = cords.Row
+	}
+	return row*board.ChessboardSize + cords.Col
+}
+
+func adjacentRows(pawnRows [board.ChessboardSize]uint8, col int) uint8 {
+	var rows uint8
+	if col > 0 {
+		rows |= pawnRows[col-1]
+	}
+	if col < board.ChessboardSize-1 {
+		rows |= pawnRows[col+1]
+	}
+	return rows
+}
+
+func isOnBoard(col int, row int) bool {
+	return col >= 0 && col < board.ChessboardSize && row >= 0 && row < board.ChessboardSize
+}
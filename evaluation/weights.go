@@ -0,0 +1,192 @@
+package evaluation
+
+import "chess/board"
+
+// Score is a pair of values for the middlegame and the endgame, which are blended by the game phase
+type Score struct {
+	Middlegame int
+	Endgame    int
+}
+
+func (score Score) add(other Score) Score {
+	return Score{Middlegame: score.Middlegame + other.Middlegame, Endgame: score.Endgame + other.Endgame}
+}
+
+func (score Score) times(count int) Score {
+	return Score{Middlegame: score.Middlegame * count, Endgame: score.Endgame * count}
+}
+
+// Weights hold every value the evaluation is made of, in centipawns. Piece-square tables are seen from
+// the white side with a8 first, black figures use them mirrored vertically
+type Weights struct {
+	Material     [board.Queen + 1]Score
+	PieceSquare  [board.Queen + 1][board.ChessboardSize * board.ChessboardSize]Score
+	DoubledPawn  Score
+	IsolatedPawn Score
+	// PassedPawn is indexed by the row of the pawn counted from its own side
+	PassedPawn [board.ChessboardSize]Score
+	// KingShield is given for every own pawn on the two rows in front of the king
+	KingShield Score
+	// KingZoneAttack is given for every attack of the opponent on the king field and the fields around it
+	KingZoneAttack Score
+	// Mobility is given for every field attacked by the figure which is neither taken by an own figure
+	// nor attacked by an opponent pawn
+	Mobility   [board.Queen + 1]Score
+	BishopPair Score
+	// Phase weights of the figures sum up to the phase of the position, from 0 for the bare endgame
+	// up to the sum of the weights of all the figures of the initial position for the middlegame
+	Phase [board.Queen + 1]int
+}
+
+// maxPhase returns phase of the position with all the figures on the board
+func (weights *Weights) maxPhase() int {
+	return 2 * (8*weights.Phase[board.Pawn] + 2*weights.Phase[board.Rook] + 2*weights.Phase[board.Knight] +
+		2*weights.Phase[board.Bishop] + weights.Phase[board.Queen])
+}
+
+// DefaultWeights returns hand-picked weights
+func DefaultWeights() Weights {
+	weights := Weights{
+		DoubledPawn:    Score{Middlegame: -10, Endgame: -20},
+		IsolatedPawn:   Score{Middlegame: -10, Endgame: -15},
+		KingShield:     Score{Middlegame: 10, Endgame: 0},
+		KingZoneAttack: Score{Middlegame: -8, Endgame: -2},
+		BishopPair:     Score{Middlegame: 30, Endgame: 50},
+		PassedPawn: [board.ChessboardSize]Score{
+			{}, {Middlegame: 5, Endgame: 10}, {Middlegame: 10, Endgame: 20}, {Middlegame: 15, Endgame: 35},
+			{Middlegame: 25, Endgame: 60}, {Middlegame: 40, Endgame: 100}, {Middlegame: 60, Endgame: 150}, {},
+		},
+	}
+	weights.Material[board.Pawn] = Score{Middlegame: 100, Endgame: 120}
+	weights.Material[board.Knight] = Score{Middlegame: 320, Endgame: 300}
+	weights.Material[board.Bishop] = Score{Middlegame: 330, Endgame: 320}
+	weights.Material[board.Rook] = Score{Middlegame: 500, Endgame: 540}
+	weights.Material[board.Queen] = Score{Middlegame: 900, Endgame: 950}
+
+	weights.Mobility[board.Knight] = Score{Middlegame: 4, Endgame: 4}
+	weights.Mobility[board.Bishop] = Score{Middlegame: 5, Endgame: 5}
+	weights.Mobility[board.Rook] = Score{Middlegame: 2, Endgame: 4}
+	weights.Mobility[board.Queen] = Score{Middlegame: 1, Endgame: 2}
+
+	weights.Phase[board.Knight] = 1
+	weights.Phase[board.Bishop] = 1
+	weights.Phase[board.Rook] = 2
+	weights.Phase[board.Queen] = 4
+
+	weights.PieceSquare[board.Pawn] = pieceSquareScores(pawnMiddlegameTable, pawnEndgameTable)
+	weights.PieceSquare[board.Knight] = pieceSquareScores(knightTable, knightTable)
+	weights.PieceSquare[board.Bishop] = pieceSquareScores(bishopTable, bishopTable)
+	weights.PieceSquare[board.Rook] = pieceSquareScores(rookMiddlegameTable, rookEndgameTable)
+	weights.PieceSquare[board.Queen] = pieceSquareScores(queenTable, queenTable)
+	weights.PieceSquare[board.King] = pieceSquareScores(kingMiddlegameTable, kingEndgameTable)
+	return weights
+}
+
+type pieceSquareTable = [board.ChessboardSize * board.ChessboardSize]int
+
+func pieceSquareScores(middlegame pieceSquareTable, endgame pieceSquareTable) [board.ChessboardSize * board.ChessboardSize]Score {
+	var scores [board.ChessboardSize * board.ChessboardSize]Score
+	for i := range scores {
+		scores[i] = Score{Middlegame: middlegame[i], Endgame: endgame[i]}
+	}
+	return scores
+}
+
+var pawnMiddlegameTable = pieceSquareTable{
+	0, 0, 0, 0, 0, 0, 0, 0,
+	50, 50, 50, 50, 50, 50, 50, 50,
+	10, 10, 20, 30, 30, 20, 10, 10,
+	5, 5, 10, 25, 25, 10, 5, 5,
+	0, 0, 0, 20, 20, 0, 0, 0,
+	5, -5, -10, 0, 0, -10, -5, 5,
+	5, 10, 10, -20, -20, 10, 10, 5,
+	0, 0, 0, 0, 0, 0, 0, 0,
+}
+
+var pawnEndgameTable = pieceSquareTable{
+	0, 0, 0, 0, 0, 0, 0, 0,
+	80, 80, 80, 80, 80, 80, 80, 80,
+	50, 50, 50, 50, 50, 50, 50, 50,
+	30, 30, 30, 30, 30, 30, 30, 30,
+	20, 20, 20, 20, 20, 20, 20, 20,
+	10, 10, 10, 10, 10, 10, 10, 10,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+}
+
+var knightTable = pieceSquareTable{
+	-50, -40, -30, -30, -30, -30, -40, -50,
+	-40, -20, 0, 0, 0, 0, -20, -40,
+	-30, 0, 10, 15, 15, 10, 0, -30,
+	-30, 5, 15, 20, 20, 15, 5, -30,
+	-30, 0, 15, 20, 20, 15, 0, -30,
+	-30, 5, 10, 15, 15, 10, 5, -30,
+	-40, -20, 0, 5, 5, 0, -20, -40,
+	-50, -40, -30, -30, -30, -30, -40, -50,
+}
+
+var bishopTable = pieceSquareTable{
+	-20, -10, -10, -10, -10, -10, -10, -20,
+	-10, 0, 0, 0, 0, 0, 0, -10,
+	-10, 0, 5, 10, 10, 5, 0, -10,
+	-10, 5, 5, 10, 10, 5, 5, -10,
+	-10, 0, 10, 10, 10, 10, 0, -10,
+	-10, 10, 10, 10, 10, 10, 10, -10,
+	-10, 5, 0, 0, 0, 0, 5, -10,
+	-20, -10, -10, -10, -10, -10, -10, -20,
+}
+
+var rookMiddlegameTable = pieceSquareTable{
+	0, 0, 0, 0, 0, 0, 0, 0,
+	5, 10, 10, 10, 10, 10, 10, 5,
+	-5, 0, 0, 0, 0, 0, 0, -5,
+	-5, 0, 0, 0, 0, 0, 0, -5,
+	-5, 0, 0, 0, 0, 0, 0, -5,
+	-5, 0, 0, 0, 0, 0, 0, -5,
+	-5, 0, 0, 0, 0, 0, 0, -5,
+	0, 0, 0, 5, 5, 0, 0, 0,
+}
+
+var rookEndgameTable = pieceSquareTable{
+	0, 0, 0, 0, 0, 0, 0, 0,
+	10, 10, 10, 10, 10, 10, 10, 10,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+}
+
+var queenTable = pieceSquareTable{
+	-20, -10, -10, -5, -5, -10, -10, -20,
+	-10, 0, 0, 0, 0, 0, 0, -10,
+	-10, 0, 5, 5, 5, 5, 0, -10,
+	-5, 0, 5, 5, 5, 5, 0, -5,
+	-5, 0, 5, 5, 5, 5, 0, -5,
+	-10, 0, 5, 5, 5, 5, 0, -10,
+	-10, 0, 0, 0, 0, 0, 0, -10,
+	-20, -10, -10, -5, -5, -10, -10, -20,
+}
+
+var kingMiddlegameTable = pieceSquareTable{
+	-30, -40, -40, -50, -50, -40, -40, -30,
+	-30, -40, -40, -50, -50, -40, -40, -30,
+	-30, -40, -40, -50, -50, -40, -40, -30,
+	-30, -40, -40, -50, -50, -40, -40, -30,
+	-20, -30, -30, -40, -40, -30, -30, -20,
+	-10, -20, -20, -20, -20, -20, -20, -10,
+	20, 20, 0, 0, 0, 0, 20, 20,
+	20, 30, 10, 0, 0, 10, 30, 20,
+}
+
+var kingEndgameTable = pieceSquareTable{
+	-50, -40, -30, -20, -20, -30, -40, -50,
+	-30, -20, -10, 0, 0, -10, -20, -30,
+	-30, -10, 20, 30, 30, 20, -10, -30,
+	-30, -10, 30, 40, 40, 30, -10, -30,
+	-30, -10, 30, 40, 40, 30, -10, -30,
+	-30, -10, 20, 30, 30, 20, -10, -30,
+	-30, -30, 0, 0, 0, 0, -30, -30,
+	-50, -30, -30, -30, -30, -30, -30, -50,
+}
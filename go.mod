@@ -2,11 +2,13 @@ module chess
 
 go 1.21
 
-require github.com/stretchr/testify v1.8.4
+require (
+	github.com/deckarep/golang-set/v2 v2.5.0
+	github.com/stretchr/testify v1.8.4
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/deckarep/golang-set/v2 v2.5.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,38 @@
+package search
+
+import "chess/board"
+
+// captureOrderKey sorts captures by the most valuable victim first, then by the least valuable attacker.
+// Promotions count as capturing the figure promoted to, quiet moves get zero. The victim is weighted above
+// the most valuable attacker, so captures always get positive keys, even if the king captures
+func captureOrderKey(position *board.Board, move *board.GeneratedMove) int {
+	victimValue := 0
+	if captured, isCapture := position.CapturedFigure(move); isCapture {
+		victimValue = captured.FigureType.Value()
+	}
+	victimValue += move.PromoteToType().Value()
+	if victimValue == 0 {
+		return 0
+	}
+	return (board.SEEValue(board.King)+1)*victimValue - board.SEEValue(move.Departure().Figure.FigureType)
+}
+
+// orderCaptures sorts moves by captureOrderKey keeping the order of moves with equal keys.
+// Insertion sort is enough for the short lists and doesn't allocate
+func orderCaptures(position *board.Board, moves []board.GeneratedMove) {
+	var keys [256]int
+	if len(moves) > len(keys) {
+		return
+	}
+	for i := range moves {
+		keys[i] = captureOrderKey(position, &moves[i])
+	}
+	for i := 1; i < len(moves); i++ {
+		move, key := moves[i], keys[i]
+		j := i
+		for ; j > 0 && keys[j-1] < key; j-- {
+			moves[j], keys[j] = moves[j-1], keys[j-1]
+		}
+		moves[j], keys[j] = move, key
+	}
+}
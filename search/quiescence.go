@@ -0,0 +1,91 @@
+package search
+
+import "chess/board"
+
+// deltaMargin is added to the value of the captured figure when deciding whether a capture can raise alpha
+const deltaMargin = 200
+
+// quiescence searches captures and promotions until the position is quiet, so the leaves aren't evaluated
+// in the middle of an exchange. The side to move may stand pat on the evaluation instead of capturing.
+// Captures which can't raise alpha even with a margin, and captures losing material by SEE are skipped.
+// In check all evasions are searched, so mates are found; qply counts plies from the main search leaf
+func (searcher *Searcher) quiescence(position *board.Board, ply int, qply int, alpha int, beta int) int {
+	searcher.pvLength[ply] = 0
+	searcher.nodes++
+	if searcher.shouldStop() {
+		return 0
+	}
+	if qply == 0 && ply > 0 && searcher.isDraw(position) {
+		return 0
+	}
+	if ply >= MaxPly-1 {
+		return searcher.evaluator(position)
+	}
+
+	inCheck := position.IsInCheck(position.SideToMove())
+	standPat := -Infinity
+	if !inCheck {
+		standPat = searcher.evaluator(position)
+		if standPat >= beta {
+			return beta
+		}
+		alpha = max(alpha, standPat)
+	}
+
+	generator := searcher.generators[ply]
+	generator.Reset(position)
+	_, moves, _ := generator.NextStage()
+	if inCheck && len(moves) == 0 {
+		return matedScore(ply)
+	}
+	orderCaptures(position, moves)
+	if !inCheck && qply == 0 && searcher.quiescenceChecks {
+		_, quiets, _ := generator.NextStage()
+		// capping the capacity keeps appended checks from overwriting the quiet moves in the generator buffer
+		moves = moves[:len(moves):len(moves)]
+		for i := range quiets {
+			if position.GivesCheck(&quiets[i]) {
+				moves = append(moves, quiets[i])
+			}
+		}
+	}
+
+	for i := range moves {
+		if !inCheck && !searcher.isWorthSearching(position, &moves[i], standPat, alpha) {
+			continue
+		}
+		move := moves[i].Move()
+		position.MakeMove(move)
+		searcher.path = append(searcher.path, position.Hash())
+		score := -searcher.quiescence(position, ply+1, qply+1, -beta, -alpha)
+		searcher.path = searcher.path[:len(searcher.path)-1]
+		position.UnmakeMove()
+		if searcher.stopped {
+			return 0
+		}
+		if score > alpha {
+			alpha = score
+			searcher.updatePV(ply, move)
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+	return alpha
+}
+
+// isWorthSearching applies delta and SEE pruning to a move of a quiescence node which is not in check.
+// Promotions and quiet checks are always searched
+func (searcher *Searcher) isWorthSearching(position *board.Board, move *board.GeneratedMove, standPat int, alpha int) bool {
+	if move.PromoteToType() != board.EmptyType {
+		return true
+	}
+	captured, isCapture := position.CapturedFigure(move)
+	if !isCapture {
+		return true
+	}
+	if standPat+100*captured.FigureType.Value()+deltaMargin <= alpha {
+		return false
+	}
+	return position.SEEAtLeast(move, 0)
+}
@@ -0,0 +1,44 @@
+package search
+
+import "chess/board"
+
+const (
+	// MaxPly limits how deep the search goes from the root, including extensions
+	MaxPly = 128
+	// MateScore is the score of the side giving mate on the board. Mate found n plies from the root scores
+	// MateScore-n, so shorter mates are preferred
+	MateScore = 32000
+	// Infinity is greater than any score
+	Infinity = MateScore + 1
+)
+
+// Evaluator scores the position in centipawns from the point of view of the side to move
+type Evaluator func(position *board.Board) int
+
+// MaterialEvaluator scores the position by the material balance only
+func MaterialEvaluator(position *board.Board) int {
+	side := position.SideToMove()
+	return 100 * (position.Material(side) - position.Material(side.Opposite()))
+}
+
+// IsMateScore checks whether the score means a forced mate for either side
+func IsMateScore(score int) bool {
+	return score > MateScore-MaxPly || score < -MateScore+MaxPly
+}
+
+// MateIn returns number of moves to the mate for mate scores: positive if the side to move mates,
+// negative if it gets mated. It returns 0 for other scores
+func MateIn(score int) int {
+	switch {
+	case score > MateScore-MaxPly:
+		return (MateScore - score + 1) / 2
+	case score < -MateScore+MaxPly:
+		return -(MateScore + score) / 2
+	}
+	return 0
+}
+
+// matedScore is the score of the side to move checkmated at given ply
+func matedScore(ply int) int {
+	return -MateScore + ply
+}
@@ -0,0 +1,292 @@
+package search
+
+import (
+	"chess/board"
+	"chess/session"
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoLegalMoves = errors.New("position has no legal moves")
+
+// stopCheckInterval is number of nodes searched between checks of the limits
+const stopCheckInterval = 1024
+
+// Limits tell when the search stops. Zero values mean no limit, except that the depth is capped by MaxPly
+type Limits struct {
+	Depth int
+	Nodes int
+	Time  time.Duration
+}
+
+// Result holds the outcome of the deepest completed iteration. Score is in centipawns from the point of view
+// of the side to move, see MateIn for mate scores
+type Result struct {
+	Move  session.MoveRequest
+	Score int
+	PV    []session.MoveRequest
+	Depth int
+	Nodes int
+	// Hashfull is per mille of the transposition table used, or 0 if the searcher has no table
+	Hashfull int
+}
+
+// Searcher finds the best move by iterative deepening negamax with alpha-beta pruning.
+// It keeps its buffers between searches, so it should be reused, but not by several goroutines at once
+type Searcher struct {
+	evaluator Evaluator
+	table     *TranspositionTable
+	// quiescenceChecks makes the quiescence search try quiet checks at its first ply
+	quiescenceChecks bool
+	generators       [MaxPly]*board.StagedMoveGenerator
+	pv               [MaxPly][MaxPly]board.Move
+	pvLength         [MaxPly]int
+	previousPV       []board.Move
+	path             []uint64
+	nodes            int
+	limits           Limits
+	ctx              context.Context
+	stoppable        bool
+	stopped          bool
+}
+
+// MakeSearcher creates searcher scoring leaf positions with given evaluator. MaterialEvaluator is used if it is nil
+func MakeSearcher(evaluator Evaluator) *Searcher {
+	if evaluator == nil {
+		evaluator = MaterialEvaluator
+	}
+	searcher := &Searcher{evaluator: evaluator, path: make([]uint64, 0, MaxPly)}
+	for ply := range searcher.generators {
+		searcher.generators[ply] = board.MakeStagedMoveGenerator()
+	}
+	return searcher
+}
+
+// UseTranspositionTable makes the searcher keep results in given table, nil turns the table off.
+// The table may be shared by searchers running in parallel. Call NewSearch of the table before every search
+// unless the table should keep the entries of the previous searches as fresh ones
+func (searcher *Searcher) UseTranspositionTable(table *TranspositionTable) {
+	searcher.table = table
+}
+
+// UseQuiescenceChecks makes the quiescence search try quiet moves giving check at its first ply besides
+// captures and promotions. It finds more forks and mating attacks behind the horizon at the cost of more nodes
+func (searcher *Searcher) UseQuiescenceChecks(enabled bool) {
+	searcher.quiescenceChecks = enabled
+}
+
+// Search searches the position deeper and deeper until a limit is reached or the context is done.
+// The first iteration always completes, so a move is returned even if the search is stopped early.
+// The position is not changed. ErrNoLegalMoves is returned for checkmate and stalemate positions
+func (searcher *Searcher) Search(ctx context.Context, position *board.Board, limits Limits) (Result, error) {
+	if limits.Time > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Time)
+		defer cancel()
+	}
+	maxDepth := limits.Depth
+	if maxDepth <= 0 || maxDepth >= MaxPly {
+		maxDepth = MaxPly - 1
+	}
+	searcher.ctx = ctx
+	searcher.limits = limits
+	searcher.nodes = 0
+	searcher.stoppable = false
+	searcher.stopped = false
+	searcher.previousPV = searcher.previousPV[:0]
+
+	root := position.Copy()
+	searcher.path = append(searcher.path[:0], root.Hash())
+
+	var result Result
+	for depth := 1; depth <= maxDepth; depth++ {
+		score := searcher.negamax(&root, depth, 0, -Infinity, Infinity)
+		if searcher.stopped {
+			break
+		}
+		if searcher.pvLength[0] == 0 {
+			result.Score = score
+			return result, ErrNoLegalMoves
+		}
+		searcher.previousPV = append(searcher.previousPV[:0], searcher.pv[0][:searcher.pvLength[0]]...)
+		result = Result{
+			Move:  moveRequest(searcher.previousPV[0]),
+			Score: score,
+			PV:    moveRequests(searcher.previousPV),
+			Depth: depth,
+		}
+		searcher.stoppable = true
+		if ctx.Err() != nil || IsMateScore(score) && MateScore-abs(score) <= depth {
+			break
+		}
+	}
+	result.Nodes = searcher.nodes
+	if searcher.table != nil {
+		result.Hashfull = searcher.table.Hashfull()
+	}
+	return result, nil
+}
+
+// negamax returns the score of the position for the side to move, failing hard within alpha and beta.
+// Once the depth runs out, the quiescence search takes over
+func (searcher *Searcher) negamax(position *board.Board, depth int, ply int, alpha int, beta int) int {
+	if depth <= 0 {
+		return searcher.quiescence(position, ply, 0, alpha, beta)
+	}
+	searcher.pvLength[ply] = 0
+	searcher.nodes++
+	if searcher.shouldStop() {
+		return 0
+	}
+	if ply > 0 {
+		if searcher.isDraw(position) {
+			return 0
+		}
+		alpha = max(alpha, matedScore(ply))
+		beta = min(beta, -matedScore(ply+1))
+		if alpha >= beta {
+			return alpha
+		}
+	}
+	if ply >= MaxPly-1 {
+		return searcher.evaluator(position)
+	}
+
+	hash := searcher.path[len(searcher.path)-1]
+	var hashMove *session.MoveRequest
+	if searcher.table != nil {
+		if entry, found := searcher.table.Probe(hash, ply); found {
+			if entry.HasMove {
+				hashMove = &entry.Move
+			}
+			if ply > 0 && entry.Depth >= depth && (entry.Bound == ExactBound ||
+				entry.Bound == LowerBound && entry.Score >= beta || entry.Bound == UpperBound && entry.Score <= alpha) {
+				return max(alpha, min(beta, entry.Score))
+			}
+		}
+	}
+
+	moves := searcher.orderedMoves(position, ply, hashMove)
+	if len(moves) == 0 {
+		if position.IsInCheck(position.SideToMove()) {
+			return matedScore(ply)
+		}
+		return 0
+	}
+	originalAlpha := alpha
+	var bestMove board.Move
+	for _, generated := range moves {
+		move := generated.Move()
+		position.MakeMove(move)
+		searcher.path = append(searcher.path, position.Hash())
+		score := -searcher.negamax(position, depth-1, ply+1, -beta, -alpha)
+		searcher.path = searcher.path[:len(searcher.path)-1]
+		position.UnmakeMove()
+		if searcher.stopped {
+			return 0
+		}
+		if score > alpha {
+			alpha = score
+			bestMove = move
+			searcher.updatePV(ply, move)
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+	if searcher.table != nil {
+		bound := ExactBound
+		if alpha >= beta {
+			bound = LowerBound
+		} else if alpha <= originalAlpha {
+			bound = UpperBound
+		}
+		searcher.table.Store(hash, ply, depth, alpha, bound, bestMove)
+	}
+	return alpha
+}
+
+// orderedMoves returns legal moves with the move from the transposition table or the previous principal variation
+// first, then captures and promotions ordered by captureOrderKey, then quiet moves
+func (searcher *Searcher) orderedMoves(position *board.Board, ply int, hashMove *session.MoveRequest) []board.GeneratedMove {
+	moves := searcher.generators[ply].LegalMoves(position)
+	orderCaptures(position, moves)
+	var firstMove session.MoveRequest
+	if hashMove != nil {
+		firstMove = *hashMove
+	} else if ply < len(searcher.previousPV) {
+		firstMove = moveRequest(searcher.previousPV[ply])
+	} else {
+		return moves
+	}
+	for i, move := range moves {
+		if moveRequest(&moves[i]) == firstMove {
+			copy(moves[1:i+1], moves[:i])
+			moves[0] = move
+			break
+		}
+	}
+	return moves
+}
+
+func (searcher *Searcher) updatePV(ply int, move board.Move) {
+	searcher.pv[ply][0] = move
+	childLength := searcher.pvLength[ply+1]
+	copy(searcher.pv[ply][1:childLength+1], searcher.pv[ply+1][:childLength])
+	searcher.pvLength[ply] = childLength + 1
+}
+
+// isDraw detects the fifty-move rule, repetition of a position met earlier in the search and bare material
+func (searcher *Searcher) isDraw(position *board.Board) bool {
+	halfmoveClock := position.HalfmoveClock()
+	if halfmoveClock >= 100 {
+		return true
+	}
+	last := len(searcher.path) - 1
+	for i := last - 2; i >= 0 && i >= last-halfmoveClock; i -= 2 {
+		if searcher.path[i] == searcher.path[last] {
+			return true
+		}
+	}
+	return !position.HasMatingMaterial(board.White) && !position.HasMatingMaterial(board.Black)
+}
+
+// shouldStop checks limits every stopCheckInterval nodes once the first iteration is completed
+func (searcher *Searcher) shouldStop() bool {
+	if searcher.stopped {
+		return true
+	}
+	if !searcher.stoppable {
+		return false
+	}
+	if searcher.limits.Nodes > 0 && searcher.nodes >= searcher.limits.Nodes {
+		searcher.stopped = true
+	} else if searcher.nodes%stopCheckInterval == 0 && searcher.ctx.Err() != nil {
+		searcher.stopped = true
+	}
+	return searcher.stopped
+}
+
+func moveRequest(move board.MoveFields) session.MoveRequest {
+	return session.MoveRequest{
+		DepartureCords:   move.Departure().Cords,
+		DestinationCords: move.Destination().Cords,
+		PromoteToType:    board.PromotionOf(move),
+	}
+}
+
+func moveRequests(moves []board.Move) []session.MoveRequest {
+	requests := make([]session.MoveRequest, 0, len(moves))
+	for _, move := range moves {
+		requests = append(requests, moveRequest(move))
+	}
+	return requests
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
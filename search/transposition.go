@@ -0,0 +1,213 @@
+package search
+
+import (
+	"chess/board"
+	"chess/session"
+	"math"
+	"sync/atomic"
+)
+
+type Bound uint8
+
+const (
+	NoBound Bound = iota
+	// ExactBound is stored when the score lies within the window
+	ExactBound Bound = iota
+	// LowerBound is stored when the score failed high, the real score is at least the stored one
+	LowerBound Bound = iota
+	// UpperBound is stored when the score failed low, the real score is at most the stored one
+	UpperBound Bound = iota
+)
+
+const (
+	bucketSize = 4
+	entryBytes = 16
+	ageMask    = 1<<6 - 1
+	moveMask   = 1<<16 - 1
+	// hashfullSample is number of entries looked at to estimate how full the table is
+	hashfullSample = 1000
+	// maxBuckets limits the table to 64 GB
+	maxBuckets = 1 << 30
+)
+
+// TTEntry is what the transposition table knows about a position. Mate scores are relative to the ply
+// the entry was probed at
+type TTEntry struct {
+	Move    session.MoveRequest
+	HasMove bool
+	Score   int
+	Depth   int
+	Bound   Bound
+	Age     int
+}
+
+// ttSlot keeps the key XOR-ed with the data, so a slot torn by concurrent writes is detected on probe
+// and ignored instead of being read as a wrong position
+type ttSlot struct {
+	check atomic.Uint64
+	data  atomic.Uint64
+}
+
+// TranspositionTable remembers search results of positions by their Zobrist hashes. It is safe for use
+// by multiple goroutines without locking. Entries are kept in buckets, an entry of the same position
+// is replaced first, then an empty one, then the shallowest one left by older searches
+type TranspositionTable struct {
+	slots []ttSlot
+	mask  uint64
+	age   atomic.Uint32
+}
+
+// MakeTranspositionTable creates table taking at most given number of megabytes, but at least one bucket.
+// Negative sizes are taken as zero, sizes above 64 GB as 64 GB
+func MakeTranspositionTable(megabytes int) *TranspositionTable {
+	bytes := uint64(max(megabytes, 0)) << 20
+	buckets := uint64(1)
+	for buckets < maxBuckets && buckets*2*bucketSize*entryBytes <= bytes {
+		buckets *= 2
+	}
+	return &TranspositionTable{slots: make([]ttSlot, buckets*bucketSize), mask: buckets - 1}
+}
+
+// NewSearch ages the entries stored so far, so they are replaced before the entries of the new search.
+// It should be called once before every search, not by every goroutine of a search
+func (table *TranspositionTable) NewSearch() {
+	table.age.Add(1)
+}
+
+// Clear removes all entries. It must not run concurrently with a search
+func (table *TranspositionTable) Clear() {
+	for i := range table.slots {
+		table.slots[i].check.Store(0)
+		table.slots[i].data.Store(0)
+	}
+	table.age.Store(0)
+}
+
+// Probe returns the entry of the position with given hash probed at given ply from the root
+func (table *TranspositionTable) Probe(hash uint64, ply int) (TTEntry, bool) {
+	bucket := table.bucket(hash)
+	for i := range bucket {
+		data := bucket[i].data.Load()
+		if data != 0 && bucket[i].check.Load()^data == hash {
+			entry := unpackEntry(data)
+			entry.Score = scoreFromTable(entry.Score, ply)
+			return entry, true
+		}
+	}
+	return TTEntry{}, false
+}
+
+// Store saves result of searching the position with given hash at given ply. The move may be nil
+// when no move has been found best, then the move stored before for the position is kept
+func (table *TranspositionTable) Store(hash uint64, ply int, depth int, score int, bound Bound, move board.Move) {
+	bucket := table.bucket(hash)
+	age := int(table.age.Load() & ageMask)
+	victim := &bucket[0]
+	victimQuality := math.MaxInt
+	moveBits := packMove(move)
+	for i := range bucket {
+		slot := &bucket[i]
+		data := slot.data.Load()
+		if data == 0 {
+			if victimQuality > math.MinInt {
+				victim, victimQuality = slot, math.MinInt
+			}
+			continue
+		}
+		if slot.check.Load()^data == hash {
+			victim = slot
+			if move == nil {
+				moveBits = data & moveMask
+			}
+			break
+		}
+		entry := unpackEntry(data)
+		if quality := entry.Depth - 8*((age-entry.Age)&ageMask); quality < victimQuality {
+			victim, victimQuality = slot, quality
+		}
+	}
+
+	data := packEntry(moveBits, scoreToTable(score, ply), depth, bound, age)
+	victim.check.Store(hash ^ data)
+	victim.data.Store(data)
+}
+
+// Hashfull returns per mille of the entries used by the current search, estimated by a sample of the table
+func (table *TranspositionTable) Hashfull() int {
+	sample := min(hashfullSample, len(table.slots))
+	age := int(table.age.Load() & ageMask)
+	used := 0
+	for i := 0; i < sample; i++ {
+		if data := table.slots[i].data.Load(); data != 0 && unpackEntry(data).Age == age {
+			used++
+		}
+	}
+	return used * 1000 / sample
+}
+
+func (table *TranspositionTable) bucket(hash uint64) []ttSlot {
+	start := (hash & table.mask) * bucketSize
+	return table.slots[start : start+bucketSize]
+}
+
+// scoreToTable makes mate scores relative to the position being stored instead of the root,
+// since the position can be met at a different ply later
+func scoreToTable(score int, ply int) int {
+	if score > MateScore-MaxPly {
+		return score + ply
+	} else if score < -MateScore+MaxPly {
+		return score - ply
+	}
+	return score
+}
+
+func scoreFromTable(score int, ply int) int {
+	if score > MateScore-MaxPly {
+		return score - ply
+	} else if score < -MateScore+MaxPly {
+		return score + ply
+	}
+	return score
+}
+
+// Entry data layout: bits 0-5 departure, 6-11 destination, 12-14 promotion type, 15 move flag, 16-31 score,
+// 32-39 depth, 40-41 bound, 42-47 age. Bound is never NoBound, so the data of a stored entry is never zero
+func packEntry(moveBits uint64, score int, depth int, bound Bound, age int) uint64 {
+	return moveBits | uint64(uint16(int16(score)))<<16 | uint64(uint8(max(depth, 0)))<<32 |
+		uint64(bound)<<40 | uint64(age&ageMask)<<42
+}
+
+func unpackEntry(data uint64) TTEntry {
+	entry := TTEntry{
+		HasMove: data&(1<<15) != 0,
+		Score:   int(int16(uint16(data >> 16))),
+		Depth:   int(uint8(data >> 32)),
+		Bound:   Bound(data >> 40 & 3),
+		Age:     int(data >> 42 & ageMask),
+	}
+	if entry.HasMove {
+		entry.Move = session.MoveRequest{
+			DepartureCords:   squareCords(int(data & 63)),
+			DestinationCords: squareCords(int(data >> 6 & 63)),
+			PromoteToType:    board.FigureType(data >> 12 & 7),
+		}
+	}
+	return entry
+}
+
+func packMove(move board.Move) uint64 {
+	if move == nil {
+		return 0
+	}
+	request := moveRequest(move)
+	return uint64(squareIndex(request.DepartureCords)) | uint64(squareIndex(request.DestinationCords))<<6 |
+		uint64(request.PromoteToType)<<12 | 1<<15
+}
+
+func squareIndex(cords board.Cords) int {
+	return cords.Row*board.ChessboardSize + cords.Col
+}
+
+func squareCords(index int) board.Cords {
+	return board.Cords{Col: index % board.ChessboardSize, Row: index / board.ChessboardSize}
+}
@@ -0,0 +1,119 @@
+package session
+
+import (
+	"chess/board"
+	"sync"
+)
+
+type EventType int
+
+const (
+	MoveAppliedEvent      EventType = iota
+	MoveRejectedEvent     EventType = iota
+	CheckEvent            EventType = iota
+	GameOverEvent         EventType = iota
+	DrawOfferedEvent      EventType = iota
+	FlagEvent             EventType = iota
+	MoveUndoneEvent       EventType = iota
+	MoveRedoneEvent       EventType = iota
+	TakebackAcceptedEvent EventType = iota
+)
+
+// Event describes something that happened in the session. Fields not related to the event type are left empty:
+// Record is set for applied, undone and redone moves and checks, Request and Err for rejected moves,
+// Result and Termination for finished games. Side is the side which moved, offered a draw, ran out of time
+// or accepted a takeback
+type Event struct {
+	Type        EventType
+	Side        board.FigureSide
+	Record      *MoveRecord
+	Request     MoveRequest
+	Err         error
+	Result      GameResult
+	Termination Termination
+}
+
+// Listener receives session events synchronously, in the goroutine which changed the session
+type Listener func(event Event)
+
+type subscription struct {
+	id       int
+	listener Listener
+	removed  bool
+}
+
+// Subscribe registers listener called on every event and returns function which unregisters it.
+// Listeners may unsubscribe while an event is dispatched, a removed listener isn't called anymore
+func (session *Session) Subscribe(listener Listener) (unsubscribe func()) {
+	session.lastSubscriptionId++
+	id := session.lastSubscriptionId
+	session.subscriptions = append(session.subscriptions, &subscription{id: id, listener: listener})
+	return func() {
+		for i, candidate := range session.subscriptions {
+			if candidate.id == id {
+				candidate.removed = true
+				// a new slice keeps the one being dispatched over untouched
+				remaining := make([]*subscription, 0, len(session.subscriptions)-1)
+				remaining = append(remaining, session.subscriptions[:i]...)
+				session.subscriptions = append(remaining, session.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Events returns channel receiving every event of the session and function which unsubscribes and closes it.
+// While the channel is open events are never dropped: once the buffer is full, the session waits
+// for the channel to be read. Events dispatched after the close function is called are dropped
+func (session *Session) Events(buffer int) (<-chan Event, func()) {
+	channel := makeEventChannel(buffer)
+	unsubscribe := session.Subscribe(channel.send)
+	return channel.events, func() {
+		channel.close()
+		unsubscribe()
+	}
+}
+
+// eventChannel delivers events to a channel which can be closed while a send is blocked on a full buffer
+type eventChannel struct {
+	events    chan Event
+	done      chan struct{}
+	mutex     sync.Mutex
+	closed    bool
+	closeOnce sync.Once
+}
+
+func makeEventChannel(buffer int) *eventChannel {
+	return &eventChannel{events: make(chan Event, buffer), done: make(chan struct{})}
+}
+
+func (channel *eventChannel) send(event Event) {
+	channel.mutex.Lock()
+	defer channel.mutex.Unlock()
+	if channel.closed {
+		return
+	}
+	select {
+	case channel.events <- event:
+	case <-channel.done:
+	}
+}
+
+// close releases a blocked send first, so it can take the mutex afterwards
+func (channel *eventChannel) close() {
+	channel.closeOnce.Do(func() {
+		close(channel.done)
+		channel.mutex.Lock()
+		defer channel.mutex.Unlock()
+		channel.closed = true
+		close(channel.events)
+	})
+}
+
+func (session *Session) emit(event Event) {
+	for _, subscription := range session.subscriptions {
+		if !subscription.removed {
+			subscription.listener(event)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package session
+
+import (
+	"chess/board"
+	"chess/clock"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MoveRecord describes a move applied in the session. Number is the full move number the move was made at
+type MoveRecord struct {
+	Move     board.Move
+	SAN      string
+	Side     board.FigureSide
+	Number   int
+	Captured board.Figure
+	Check    bool
+	Time     time.Time
+}
+
+// IsCapture checks whether the move captured a figure
+func (record MoveRecord) IsCapture() bool {
+	return record.Captured.FigureType != board.EmptyType
+}
+
+// undoneMove keeps the position after an undone move together with its record, so the move can be redone
+type undoneMove struct {
+	position board.Board
+	record   MoveRecord
+}
+
+// SetTimeSource sets source of move timestamps. System time is used by default
+func (session *Session) SetTimeSource(timeSource clock.TimeSource) {
+	session.timeSource = timeSource
+}
+
+// CapturedFigures returns figures captured by given side in the order they were captured
+func (session *Session) CapturedFigures(side board.FigureSide) []board.Figure {
+	captured := make([]board.Figure, 0)
+	for _, record := range session.MoveHistory {
+		if record.Side == side && record.IsCapture() {
+			captured = append(captured, record.Captured)
+		}
+	}
+	return captured
+}
+
+// CapturedMaterial returns total value of figures captured by given side
+func (session *Session) CapturedMaterial(side board.FigureSide) int {
+	material := 0
+	for _, figure := range session.CapturedFigures(side) {
+		material += figure.FigureType.Value()
+	}
+	return material
+}
+
+// MaterialBalance returns material of white minus material of black on the actual board.
+// Unlike captured material, it takes promotions into account
+func (session *Session) MaterialBalance() int {
+	return session.ActualBoard.Material(board.White) - session.ActualBoard.Material(board.Black)
+}
+
+// MoveText returns the move list in algebraic notation, e.g. "1. e4 e5 2. Nf3"
+func (session *Session) MoveText() string {
+	var text strings.Builder
+	for i, record := range session.MoveHistory {
+		if i > 0 {
+			text.WriteByte(' ')
+		}
+		if record.Side == board.White {
+			text.WriteString(strconv.Itoa(record.Number) + ". ")
+		} else if i == 0 {
+			text.WriteString(strconv.Itoa(record.Number) + "... ")
+		}
+		text.WriteString(record.SAN)
+	}
+	return text.String()
+}
+
+// makeMoveRecord describes given move made in the actual position, which resulted in the next position
+func (session *Session) makeMoveRecord(move board.Move, nextPosition *board.Board) MoveRecord {
+	captured, _ := session.ActualBoard.CapturedFigure(move)
+	return MoveRecord{
+		Move:     move,
+		SAN:      session.moveGenerator.SAN(*session.ActualBoard, move),
+		Side:     move.Departure().Figure.FigureSide,
+		Number:   session.ActualBoard.FullmoveNumber(),
+		Captured: captured,
+		Check:    nextPosition.IsInCheck(nextPosition.SideToMove()),
+		Time:     session.now(),
+	}
+}
+
+func (session *Session) now() time.Time {
+	if session.timeSource == nil {
+		return time.Now()
+	}
+	return session.timeSource.Now()
+}
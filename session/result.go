@@ -0,0 +1,259 @@
+package session
+
+import (
+	"chess/board"
+	"errors"
+)
+
+type GameResult int
+
+const (
+	Ongoing  GameResult = iota
+	WhiteWon GameResult = iota
+	BlackWon GameResult = iota
+	Draw     GameResult = iota
+)
+
+type Termination int
+
+const (
+	NoTermination                 Termination = iota
+	Checkmate                     Termination = iota
+	Stalemate                     Termination = iota
+	Resignation                   Termination = iota
+	DrawAgreement                 Termination = iota
+	ThreefoldRepetition           Termination = iota
+	FiftyMoveRule                 Termination = iota
+	Timeout                       Termination = iota
+	TimeoutVsInsufficientMaterial Termination = iota
+)
+
+type ActionType int
+
+const (
+	ResignAction          ActionType = iota
+	OfferDrawAction       ActionType = iota
+	AcceptDrawAction      ActionType = iota
+	DeclineDrawAction     ActionType = iota
+	WithdrawDrawAction    ActionType = iota
+	ClaimDrawAction       ActionType = iota
+	RequestTakebackAction ActionType = iota
+	AcceptTakebackAction  ActionType = iota
+	DeclineTakebackAction ActionType = iota
+)
+
+// Action is a game action other than a move. Ply is the number of moves made before the action
+type Action struct {
+	Type        ActionType
+	Side        board.FigureSide
+	Ply         int
+	Termination Termination
+}
+
+var (
+	ErrGameOver           = errors.New("game is over")
+	ErrInvalidSide        = errors.New("side is neither white nor black")
+	ErrDrawAlreadyOffered = errors.New("draw is already offered")
+	ErrNoDrawOffer        = errors.New("no draw offer to answer")
+	ErrNotYourTurn        = errors.New("side is not to move")
+	ErrInvalidDrawClaim   = errors.New("draw can't be claimed for this reason")
+	ErrDrawClaimRejected  = errors.New("draw claim is not justified")
+)
+
+// Result returns result of the game, Ongoing if it hasn't finished yet
+func (session *Session) Result() GameResult {
+	return session.result
+}
+
+// Termination returns reason the game finished with
+func (session *Session) Termination() Termination {
+	return session.termination
+}
+
+// IsOver checks whether the game has finished
+func (session *Session) IsOver() bool {
+	return session.result != Ongoing
+}
+
+// DrawOffer returns side which offered a draw, if the offer is still pending
+func (session *Session) DrawOffer() (board.FigureSide, bool) {
+	return session.drawOfferSide, session.drawOfferSide != board.EmptySide
+}
+
+// Resign finishes the game with the win of the opponent of given side
+func (session *Session) Resign(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	if session.IsOver() {
+		return ErrGameOver
+	}
+	session.recordAction(ResignAction, side, Resignation)
+	session.finish(winOf(side.Opposite()), Resignation)
+	return nil
+}
+
+// OfferDraw makes a draw offer which stays valid until the opponent answers or makes a move
+func (session *Session) OfferDraw(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	if session.IsOver() {
+		return ErrGameOver
+	}
+	if _, offered := session.DrawOffer(); offered {
+		return ErrDrawAlreadyOffered
+	}
+	session.drawOfferSide = side
+	session.recordAction(OfferDrawAction, side, NoTermination)
+	session.emit(Event{Type: DrawOfferedEvent, Side: side})
+	return nil
+}
+
+// AcceptDraw accepts the draw offered by the opponent of given side and finishes the game
+func (session *Session) AcceptDraw(side board.FigureSide) error {
+	if err := session.checkDrawOfferTo(side); err != nil {
+		return err
+	}
+	session.drawOfferSide = board.EmptySide
+	session.recordAction(AcceptDrawAction, side, DrawAgreement)
+	session.finish(Draw, DrawAgreement)
+	return nil
+}
+
+// DeclineDraw declines the draw offered by the opponent of given side
+func (session *Session) DeclineDraw(side board.FigureSide) error {
+	if err := session.checkDrawOfferTo(side); err != nil {
+		return err
+	}
+	session.drawOfferSide = board.EmptySide
+	session.recordAction(DeclineDrawAction, side, NoTermination)
+	return nil
+}
+
+// WithdrawDraw cancels the draw offered by given side
+func (session *Session) WithdrawDraw(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	if session.IsOver() {
+		return ErrGameOver
+	}
+	if session.drawOfferSide != side {
+		return ErrNoDrawOffer
+	}
+	session.drawOfferSide = board.EmptySide
+	session.recordAction(WithdrawDrawAction, side, NoTermination)
+	return nil
+}
+
+// ClaimDraw finishes the game in a draw by threefold repetition or fifty-move rule if the claim is justified.
+// Only the side to move can claim a draw
+func (session *Session) ClaimDraw(side board.FigureSide, reason Termination) error {
+	if session.IsOver() {
+		return ErrGameOver
+	}
+	if side != session.SideToMove() {
+		return ErrNotYourTurn
+	}
+	switch reason {
+	case ThreefoldRepetition:
+		if session.Repetitions() < 3 {
+			return ErrDrawClaimRejected
+		}
+	case FiftyMoveRule:
+		if session.ActualBoard.HalfmoveClock() < 100 {
+			return ErrDrawClaimRejected
+		}
+	default:
+		return ErrInvalidDrawClaim
+	}
+	session.drawOfferSide = board.EmptySide
+	session.recordAction(ClaimDrawAction, side, reason)
+	session.finish(Draw, reason)
+	return nil
+}
+
+// Repetitions returns how many times the actual position has occurred in the game including the actual occurrence
+func (session *Session) Repetitions() int {
+	repetitions := 1
+	hash := session.ActualBoard.Hash()
+	for i := range session.BoardHistory {
+		if session.BoardHistory[i].Hash() == hash && session.BoardHistory[i].SamePosition(session.ActualBoard) {
+			repetitions++
+		}
+	}
+	return repetitions
+}
+
+func (session *Session) checkDrawOfferTo(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	if session.IsOver() {
+		return ErrGameOver
+	}
+	if session.drawOfferSide == board.EmptySide || session.drawOfferSide == side {
+		return ErrNoDrawOffer
+	}
+	return nil
+}
+
+// updateResultAfterMove finishes the game if the side to move is mated or stalemated
+// and drops the draw offer once its receiver has moved
+func (session *Session) updateResultAfterMove(movingSide board.FigureSide) {
+	if session.drawOfferSide != board.EmptySide && session.drawOfferSide != movingSide {
+		session.drawOfferSide = board.EmptySide
+	}
+	sideToMove := session.SideToMove()
+	if len(session.moveGenerator.LegalMoves(*session.ActualBoard)) > 0 {
+		return
+	}
+	if session.ActualBoard.IsInCheck(sideToMove) {
+		session.finish(winOf(movingSide), Checkmate)
+	} else {
+		session.finish(Draw, Stalemate)
+	}
+}
+
+// finishOnTime finishes the game lost on time by given side. If the opponent can't checkmate, the game is drawn
+func (session *Session) finishOnTime(side board.FigureSide) {
+	session.emit(Event{Type: FlagEvent, Side: side})
+	if session.ActualBoard.HasMatingMaterial(side.Opposite()) {
+		session.finish(winOf(side.Opposite()), Timeout)
+	} else {
+		session.finish(Draw, TimeoutVsInsufficientMaterial)
+	}
+}
+
+func (session *Session) finish(result GameResult, termination Termination) {
+	session.result = result
+	session.termination = termination
+	if result != Ongoing {
+		session.emit(Event{Type: GameOverEvent, Result: result, Termination: termination})
+	}
+}
+
+func (session *Session) recordAction(actionType ActionType, side board.FigureSide, termination Termination) {
+	session.ActionHistory = append(session.ActionHistory, Action{
+		Type:        actionType,
+		Side:        side,
+		Ply:         len(session.BoardHistory),
+		Termination: termination,
+	})
+}
+
+// checkSide returns ErrInvalidSide unless the side is white or black
+func checkSide(side board.FigureSide) error {
+	if side != board.White && side != board.Black {
+		return ErrInvalidSide
+	}
+	return nil
+}
+
+func winOf(side board.FigureSide) GameResult {
+	if side == board.White {
+		return WhiteWon
+	}
+	return BlackWon
+}
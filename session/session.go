@@ -1,12 +1,29 @@
 package session
 
-import "chess/board"
+import (
+	"chess/board"
+	"chess/clock"
+	"time"
+)
 
 type Session struct {
 	ActualBoard   *board.Board
 	BoardHistory  []board.Board
-	moveSide      board.FigureSide
+	ActionHistory []Action
+	MoveHistory   []MoveRecord
 	moveGenerator board.MoveGenerator
+	result        GameResult
+	termination   Termination
+	drawOfferSide board.FigureSide
+	clock         *clock.Clock
+	redoHistory   []undoneMove
+	timeSource    clock.TimeSource
+
+	takebacksDisabled   bool
+	takebackRequestSide board.FigureSide
+
+	subscriptions      []*subscription
+	lastSubscriptionId int
 }
 
 type MoveRequest struct {
@@ -16,45 +33,126 @@ type MoveRequest struct {
 }
 
 func MakeDefaultSession() Session {
-	chessboard := board.InitDefaultBoard()
-	return Session{
-		ActualBoard:   chessboard,
-		BoardHistory:  make([]board.Board, 0, 50),
-		moveSide:      board.White,
-		moveGenerator: board.MakeMoveGenerator(board.InitValidators(chessboard)),
+	return MakeUncheckedSession(board.InitDefaultBoard())
+}
+
+// MakeSession returns session for given board. The game continues with the side to move stored in the board.
+// It returns *board.PositionError if the position can't occur in a legal game
+func MakeSession(chessBoard *board.Board) (Session, error) {
+	if problems := board.ValidatePosition(chessBoard); len(problems) > 0 {
+		return Session{}, &board.PositionError{Problems: problems}
 	}
+	return MakeUncheckedSession(chessBoard), nil
 }
 
-func MakeSession(chessBoard *board.Board) Session {
+// MakeUncheckedSession returns session for given board without checking position legality
+func MakeUncheckedSession(chessBoard *board.Board) Session {
 	return Session{
 		ActualBoard:   chessBoard,
 		BoardHistory:  make([]board.Board, 0, 50),
-		moveSide:      board.White,
-		moveGenerator: board.MakeMoveGenerator(board.InitValidators(chessBoard)),
+		MoveHistory:   make([]MoveRecord, 0, 50),
+		moveGenerator: board.MakeMoveGenerator(board.InitValidators()),
 	}
 }
 
-func (session *Session) Move(moveRequest MoveRequest) bool {
-	departure := session.ActualBoard.GetField(moveRequest.DepartureCords)
-	destination := session.ActualBoard.GetField(moveRequest.DestinationCords)
-	if departure.Figure.FigureSide != session.moveSide {
-		return false
+// SideToMove returns side which has to make the next move
+func (session *Session) SideToMove() board.FigureSide {
+	return session.ActualBoard.SideToMove()
+}
+
+// Move validates and applies requested move. It returns *board.ValidationError if the move is rejected
+// and ErrGameOver if the game has already finished
+func (session *Session) Move(moveRequest MoveRequest) error {
+	err := session.move(moveRequest)
+	if err != nil {
+		session.emit(Event{
+			Type:    MoveRejectedEvent,
+			Side:    session.SideToMove(),
+			Request: moveRequest,
+			Err:     err,
+		})
 	}
+	return err
+}
 
+func (session *Session) move(moveRequest MoveRequest) error {
+	if session.CheckFlag() {
+		return ErrGameOver
+	}
+	if !moveRequest.DepartureCords.IsOnBoard() || !moveRequest.DestinationCords.IsOnBoard() {
+		move := board.MakeMove(board.Field{Cords: moveRequest.DepartureCords}, board.Field{Cords: moveRequest.DestinationCords},
+			moveRequest.PromoteToType)
+		return &board.ValidationError{Validator: "BordersBreachValidator", Code: board.OutOfBordersCode, Move: move}
+	}
+	departure := session.ActualBoard.GetField(moveRequest.DepartureCords)
+	destination := session.ActualBoard.GetField(moveRequest.DestinationCords)
 	move := board.MakeMove(departure, destination, moveRequest.PromoteToType)
 
-	if !session.moveGenerator.IsValidMove(move) {
-		return false
+	if err := session.moveGenerator.ValidateMove(session.ActualBoard, move); err != nil {
+		return err
 	}
 
 	newActualBoard := session.ActualBoard.Move(move)
 
-	if session.moveSide == board.White {
-		session.moveSide = board.Black
-	} else {
-		session.moveSide = board.White
+	if session.clock != nil && session.clock.Press(departure.Figure.FigureSide) {
+		session.finishOnTime(departure.Figure.FigureSide)
+		return ErrGameOver
 	}
+
+	record := session.makeMoveRecord(move, &newActualBoard)
 	session.BoardHistory = append(session.BoardHistory, *session.ActualBoard)
+	session.MoveHistory = append(session.MoveHistory, record)
 	session.ActualBoard = &newActualBoard
-	return true
+	session.redoHistory = session.redoHistory[:0]
+	session.takebackRequestSide = board.EmptySide
+	session.emit(Event{Type: MoveAppliedEvent, Side: record.Side, Record: &record})
+	if record.Check {
+		session.emit(Event{Type: CheckEvent, Side: record.Side, Record: &record})
+	}
+	session.updateResultAfterMove(departure.Figure.FigureSide)
+	if session.IsOver() && session.clock != nil {
+		session.clock.Stop()
+	}
+	return nil
+}
+
+// AttachClock makes the session play with given clock and starts it for the side to move
+func (session *Session) AttachClock(gameClock *clock.Clock) {
+	session.clock = gameClock
+	if !session.IsOver() {
+		gameClock.Start(session.SideToMove())
+	}
+}
+
+// Clock returns attached clock or nil if the game is played without time control
+func (session *Session) Clock() *clock.Clock {
+	return session.clock
+}
+
+// RemainingTime returns time left for given side. It returns false if no clock is attached
+func (session *Session) RemainingTime(side board.FigureSide) (time.Duration, bool) {
+	if session.clock == nil {
+		return 0, false
+	}
+	return session.clock.Remaining(side), true
+}
+
+// CheckFlag finishes the game if a side has run out of time and reports whether the game is over
+func (session *Session) CheckFlag() bool {
+	if session.IsOver() {
+		return true
+	}
+	if session.clock == nil {
+		return false
+	}
+	if side, flagged := session.clock.Flagged(); flagged {
+		session.clock.Stop()
+		session.finishOnTime(side)
+	}
+	return session.IsOver()
+}
+
+// TryMove is the same as Move, but only reports whether the move was applied
+func (session *Session) TryMove(moveRequest MoveRequest) bool {
+	return session.Move(moveRequest) == nil
 }
@@ -0,0 +1,199 @@
+package session
+
+import (
+	"chess/board"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrStaleSequence = errors.New("session has changed since given sequence")
+
+// SyncSession is a Session safe for use by multiple goroutines. Changes are applied one at a time, and every
+// successful change increases the sequence number, so a client can make a move only in the position it has seen.
+// Event listeners are called while the session is locked and must not call the SyncSession back
+type SyncSession struct {
+	mutex    sync.RWMutex
+	session  Session
+	sequence int
+}
+
+// Snapshot is a consistent copy of the session state, which doesn't change along with the session
+type Snapshot struct {
+	Sequence        int
+	Board           board.Board
+	MoveHistory     []MoveRecord
+	Result          GameResult
+	Termination     Termination
+	DrawOffer       board.FigureSide
+	TakebackRequest board.FigureSide
+	WhiteTime       time.Duration
+	BlackTime       time.Duration
+	HasClock        bool
+}
+
+// MakeSyncSession wraps given session. The session must not be used directly afterwards
+func MakeSyncSession(session Session) *SyncSession {
+	return &SyncSession{session: session}
+}
+
+// Sequence returns number of changes made to the session
+func (syncSession *SyncSession) Sequence() int {
+	syncSession.mutex.RLock()
+	defer syncSession.mutex.RUnlock()
+	return syncSession.sequence
+}
+
+// Snapshot returns copy of the actual session state
+func (syncSession *SyncSession) Snapshot() Snapshot {
+	syncSession.mutex.RLock()
+	defer syncSession.mutex.RUnlock()
+	session := &syncSession.session
+	snapshot := Snapshot{
+		Sequence:        syncSession.sequence,
+		Board:           session.ActualBoard.Copy(),
+		MoveHistory:     append([]MoveRecord(nil), session.MoveHistory...),
+		Result:          session.result,
+		Termination:     session.termination,
+		DrawOffer:       session.drawOfferSide,
+		TakebackRequest: session.takebackRequestSide,
+	}
+	snapshot.WhiteTime, snapshot.HasClock = session.RemainingTime(board.White)
+	snapshot.BlackTime, _ = session.RemainingTime(board.Black)
+	return snapshot
+}
+
+// Move applies requested move
+func (syncSession *SyncSession) Move(moveRequest MoveRequest) error {
+	return syncSession.Update(func(session *Session) error {
+		return session.Move(moveRequest)
+	})
+}
+
+// MoveAt applies requested move only if the session hasn't changed since given sequence number.
+// Otherwise it returns ErrStaleSequence
+func (syncSession *SyncSession) MoveAt(sequence int, moveRequest MoveRequest) error {
+	return syncSession.UpdateAt(sequence, func(session *Session) error {
+		return session.Move(moveRequest)
+	})
+}
+
+// Update runs given change with exclusive access to the session. The sequence number is increased if it succeeds
+// or changes the result
+func (syncSession *SyncSession) Update(change func(session *Session) error) error {
+	syncSession.mutex.Lock()
+	defer syncSession.mutex.Unlock()
+	return syncSession.apply(change)
+}
+
+// UpdateAt is the same as Update, but runs the change only if the session hasn't changed since given sequence number
+func (syncSession *SyncSession) UpdateAt(sequence int, change func(session *Session) error) error {
+	syncSession.mutex.Lock()
+	defer syncSession.mutex.Unlock()
+	if sequence != syncSession.sequence {
+		return ErrStaleSequence
+	}
+	return syncSession.apply(change)
+}
+
+// Subscribe registers listener called on every event while the session is locked and returns function
+// which unregisters it. The listener must not call the SyncSession back
+func (syncSession *SyncSession) Subscribe(listener Listener) (unsubscribe func()) {
+	syncSession.mutex.Lock()
+	defer syncSession.mutex.Unlock()
+	unsubscribeLocked := syncSession.session.Subscribe(listener)
+	return func() {
+		syncSession.mutex.Lock()
+		defer syncSession.mutex.Unlock()
+		unsubscribeLocked()
+	}
+}
+
+// Events returns channel receiving every event of the session and function which unsubscribes and closes it.
+// The function may be called while a change waits for the channel to be read
+func (syncSession *SyncSession) Events(buffer int) (<-chan Event, func()) {
+	channel := makeEventChannel(buffer)
+	unsubscribe := syncSession.Subscribe(channel.send)
+	return channel.events, func() {
+		// closing first releases the change blocked on the channel, which holds the lock unsubscribe needs
+		channel.close()
+		unsubscribe()
+	}
+}
+
+// View runs given function with read access to the session. The function must not change the session
+func (syncSession *SyncSession) View(view func(session *Session)) {
+	syncSession.mutex.RLock()
+	defer syncSession.mutex.RUnlock()
+	view(&syncSession.session)
+}
+
+func (syncSession *SyncSession) Resign(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.Resign(side) })
+}
+
+func (syncSession *SyncSession) Undo() error {
+	return syncSession.Update(func(session *Session) error { return session.Undo() })
+}
+
+func (syncSession *SyncSession) Redo() error {
+	return syncSession.Update(func(session *Session) error { return session.Redo() })
+}
+
+func (syncSession *SyncSession) OfferDraw(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.OfferDraw(side) })
+}
+
+func (syncSession *SyncSession) AcceptDraw(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.AcceptDraw(side) })
+}
+
+func (syncSession *SyncSession) DeclineDraw(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.DeclineDraw(side) })
+}
+
+func (syncSession *SyncSession) WithdrawDraw(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.WithdrawDraw(side) })
+}
+
+func (syncSession *SyncSession) ClaimDraw(side board.FigureSide, reason Termination) error {
+	return syncSession.Update(func(session *Session) error { return session.ClaimDraw(side, reason) })
+}
+
+func (syncSession *SyncSession) RequestTakeback(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.RequestTakeback(side) })
+}
+
+func (syncSession *SyncSession) AcceptTakeback(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.AcceptTakeback(side) })
+}
+
+func (syncSession *SyncSession) DeclineTakeback(side board.FigureSide) error {
+	return syncSession.Update(func(session *Session) error { return session.DeclineTakeback(side) })
+}
+
+// CheckFlag finishes the game if a side has run out of time and reports whether the game is over
+func (syncSession *SyncSession) CheckFlag() bool {
+	over := false
+	_ = syncSession.Update(func(session *Session) error {
+		over = session.CheckFlag()
+		return errUnchanged
+	})
+	return over
+}
+
+// errUnchanged makes apply keep the sequence number unless the game has finished, without reporting
+// an error to the caller
+var errUnchanged = errors.New("session is unchanged")
+
+// apply runs the change and increases the sequence number if it succeeds. A failed change increases it as well
+// if the result has changed, e.g. when the flag falls while a move is made
+func (syncSession *SyncSession) apply(change func(session *Session) error) error {
+	session := &syncSession.session
+	result, termination := session.result, session.termination
+	err := change(session)
+	if err == nil || session.result != result || session.termination != termination {
+		syncSession.sequence++
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package session
+
+import (
+	"chess/board"
+	"errors"
+)
+
+var (
+	ErrNothingToUndo          = errors.New("no moves to undo")
+	ErrNothingToRedo          = errors.New("no moves to redo")
+	ErrTakebacksDisabled      = errors.New("takebacks are disabled")
+	ErrTakebackAlreadyPending = errors.New("takeback is already requested")
+	ErrNoTakebackRequest      = errors.New("no takeback request to answer")
+)
+
+// SetTakebacksAllowed enables or disables Undo, Redo and takeback requests, e.g. for rated games
+func (session *Session) SetTakebacksAllowed(allowed bool) {
+	session.takebacksDisabled = !allowed
+	if !allowed {
+		session.takebackRequestSide = board.EmptySide
+	}
+}
+
+// TakebacksAllowed checks whether moves can be taken back
+func (session *Session) TakebacksAllowed() bool {
+	return !session.takebacksDisabled
+}
+
+// Undo restores the position before the last move. The clock keeps time already used
+func (session *Session) Undo() error {
+	if session.takebacksDisabled {
+		return ErrTakebacksDisabled
+	}
+	return session.undo(1)
+}
+
+// Redo applies the last undone move again
+func (session *Session) Redo() error {
+	if session.takebacksDisabled {
+		return ErrTakebacksDisabled
+	}
+	if len(session.redoHistory) == 0 {
+		return ErrNothingToRedo
+	}
+	if session.IsOver() {
+		return ErrGameOver
+	}
+	last := len(session.redoHistory) - 1
+	redone := session.redoHistory[last]
+	session.redoHistory = session.redoHistory[:last]
+
+	movingSide := session.SideToMove()
+	session.BoardHistory = append(session.BoardHistory, *session.ActualBoard)
+	session.MoveHistory = append(session.MoveHistory, redone.record)
+	session.ActualBoard = &redone.position
+	session.emit(Event{Type: MoveRedoneEvent, Side: redone.record.Side, Record: &redone.record})
+	session.updateResultAfterMove(movingSide)
+	session.restartClock()
+	return nil
+}
+
+// RequestTakeback asks the opponent to take back the last move of given side. If the opponent has already replied,
+// their reply is taken back as well
+func (session *Session) RequestTakeback(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	if session.takebacksDisabled {
+		return ErrTakebacksDisabled
+	}
+	if session.takebackRequestSide != board.EmptySide {
+		return ErrTakebackAlreadyPending
+	}
+	if session.takebackPlies(side) > len(session.BoardHistory) {
+		return ErrNothingToUndo
+	}
+	session.takebackRequestSide = side
+	session.recordAction(RequestTakebackAction, side, NoTermination)
+	return nil
+}
+
+// AcceptTakeback accepts the takeback requested by the opponent of given side
+func (session *Session) AcceptTakeback(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	requestSide := session.takebackRequestSide
+	if requestSide == board.EmptySide || requestSide == side {
+		return ErrNoTakebackRequest
+	}
+	session.takebackRequestSide = board.EmptySide
+	if err := session.undo(session.takebackPlies(requestSide)); err != nil {
+		return err
+	}
+	session.redoHistory = session.redoHistory[:0]
+	session.recordAction(AcceptTakebackAction, side, NoTermination)
+	session.emit(Event{Type: TakebackAcceptedEvent, Side: side})
+	return nil
+}
+
+// DeclineTakeback declines the takeback requested by the opponent of given side
+func (session *Session) DeclineTakeback(side board.FigureSide) error {
+	if err := checkSide(side); err != nil {
+		return err
+	}
+	requestSide := session.takebackRequestSide
+	if requestSide == board.EmptySide || requestSide == side {
+		return ErrNoTakebackRequest
+	}
+	session.takebackRequestSide = board.EmptySide
+	session.recordAction(DeclineTakebackAction, side, NoTermination)
+	return nil
+}
+
+// TakebackRequest returns side which requested a takeback, if the request is still pending
+func (session *Session) TakebackRequest() (board.FigureSide, bool) {
+	return session.takebackRequestSide, session.takebackRequestSide != board.EmptySide
+}
+
+// takebackPlies returns number of moves to undo to take back the last move of given side
+func (session *Session) takebackPlies(side board.FigureSide) int {
+	if session.SideToMove() == side {
+		return 2
+	}
+	return 1
+}
+
+func (session *Session) undo(plies int) error {
+	if plies > len(session.BoardHistory) {
+		return ErrNothingToUndo
+	}
+	if session.IsOver() && session.termination != Checkmate && session.termination != Stalemate {
+		return ErrGameOver
+	}
+	undone := make([]MoveRecord, 0, plies)
+	for i := 0; i < plies; i++ {
+		last := len(session.BoardHistory) - 1
+		previousBoard := session.BoardHistory[last]
+		session.BoardHistory = session.BoardHistory[:last]
+		session.redoHistory = append(session.redoHistory, undoneMove{
+			position: *session.ActualBoard,
+			record:   session.MoveHistory[last],
+		})
+		undone = append(undone, session.MoveHistory[last])
+		session.MoveHistory = session.MoveHistory[:last]
+		session.ActualBoard = &previousBoard
+	}
+	session.finish(Ongoing, NoTermination)
+	session.drawOfferSide = board.EmptySide
+	session.takebackRequestSide = board.EmptySide
+	session.restartClock()
+	for i := range undone {
+		session.emit(Event{Type: MoveUndoneEvent, Side: undone[i].Side, Record: &undone[i]})
+	}
+	return nil
+}
+
+// restartClock runs the clock of the side to move after the position was replaced
+func (session *Session) restartClock() {
+	if session.clock == nil {
+		return
+	}
+	session.clock.Stop()
+	if !session.IsOver() {
+		session.clock.Start(session.SideToMove())
+	}
+}
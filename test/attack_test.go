@@ -0,0 +1,150 @@
+package test
+
+import (
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setFigure(chessBoard *board.Board, figureType board.FigureType, side board.FigureSide, col int, row int) board.Field {
+	field := board.Field{
+		Figure: board.Figure{FigureType: figureType, FigureSide: side, Moved: true},
+		Cords:  board.Cords{Col: col, Row: row},
+		Filled: true,
+	}
+	chessBoard.SetField(field)
+	return field
+}
+
+func fieldCords(fields []board.Field) []board.Cords {
+	cords := make([]board.Cords, 0, len(fields))
+	for _, field := range fields {
+		cords = append(cords, field.Cords)
+	}
+	return cords
+}
+
+func TestCheckers_DoubleCheck(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.White, 4, 0)
+	setFigure(&chessBoard, board.Rook, board.Black, 4, 7)
+	setFigure(&chessBoard, board.Knight, board.Black, 3, 2)
+	setFigure(&chessBoard, board.Bishop, board.Black, 7, 4)
+	setFigure(&chessBoard, board.Pawn, board.Black, 6, 2)
+
+	assert.ElementsMatch(t, []board.Cords{{Col: 4, Row: 7}, {Col: 3, Row: 2}}, fieldCords(chessBoard.Checkers(board.White)))
+	assert.True(t, chessBoard.IsInCheck(board.White))
+	assert.False(t, chessBoard.IsInCheck(board.Black))
+}
+
+func TestPins(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.White, 4, 0)
+	setFigure(&chessBoard, board.Knight, board.White, 4, 1)
+	setFigure(&chessBoard, board.Queen, board.Black, 4, 6)
+	setFigure(&chessBoard, board.Pawn, board.White, 5, 1)
+	setFigure(&chessBoard, board.Bishop, board.Black, 7, 3)
+	// rook can't pin along a diagonal
+	setFigure(&chessBoard, board.Pawn, board.White, 3, 1)
+	setFigure(&chessBoard, board.Rook, board.Black, 1, 3)
+
+	pins := chessBoard.Pins(board.White)
+
+	assert.Len(t, pins, 2)
+	knightPin, pinned := chessBoard.PinOf(board.Cords{Col: 4, Row: 1})
+	assert.True(t, pinned)
+	assert.Equal(t, board.Cords{Col: 4, Row: 6}, knightPin.Pinner.Cords)
+	assert.Equal(t, board.Direction{ColDelta: 0, RowDelta: 1}, knightPin.Direction)
+	pawnPin, pinned := chessBoard.PinOf(board.Cords{Col: 5, Row: 1})
+	assert.True(t, pinned)
+	assert.Equal(t, board.Cords{Col: 7, Row: 3}, pawnPin.Pinner.Cords)
+	_, pinned = chessBoard.PinOf(board.Cords{Col: 3, Row: 1})
+	assert.False(t, pinned)
+}
+
+func TestAttackersAndDefenders(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.Pawn, board.Black, 3, 4)
+	setFigure(&chessBoard, board.Pawn, board.White, 4, 3)
+	setFigure(&chessBoard, board.Knight, board.White, 2, 2)
+	setFigure(&chessBoard, board.Rook, board.White, 3, 0)
+	setFigure(&chessBoard, board.Queen, board.Black, 3, 7)
+	setFigure(&chessBoard, board.Pawn, board.Black, 2, 5)
+	setFigure(&chessBoard, board.Bishop, board.Black, 7, 0)
+
+	target := board.Cords{Col: 3, Row: 4}
+	assert.ElementsMatch(
+		t,
+		[]board.Cords{{Col: 4, Row: 3}, {Col: 2, Row: 2}, {Col: 3, Row: 0}},
+		fieldCords(chessBoard.Attackers(target, board.Black)),
+	)
+	assert.ElementsMatch(
+		t,
+		[]board.Cords{{Col: 3, Row: 7}, {Col: 2, Row: 5}},
+		fieldCords(chessBoard.Defenders(target, board.Black)),
+	)
+}
+
+func TestGivesCheck(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.Black, 4, 7)
+	knightField := setFigure(&chessBoard, board.Knight, board.White, 4, 3)
+	setFigure(&chessBoard, board.Rook, board.White, 4, 0)
+
+	directCheck := board.MakeMove(knightField, chessBoard.GetField(board.Cords{Col: 3, Row: 5}), board.EmptyType)
+	assert.True(t, chessBoard.GivesCheck(directCheck))
+	assert.True(t, chessBoard.GivesDiscoveredCheck(directCheck))
+
+	discoveredCheck := board.MakeMove(knightField, chessBoard.GetField(board.Cords{Col: 2, Row: 2}), board.EmptyType)
+	assert.True(t, chessBoard.GivesCheck(discoveredCheck))
+	assert.True(t, chessBoard.GivesDiscoveredCheck(discoveredCheck))
+
+	rookField := chessBoard.GetField(board.Cords{Col: 4, Row: 0})
+	quietMove := board.MakeMove(rookField, chessBoard.GetField(board.Cords{Col: 0, Row: 0}), board.EmptyType)
+	assert.False(t, chessBoard.GivesCheck(quietMove))
+	assert.False(t, chessBoard.GivesDiscoveredCheck(quietMove))
+}
+
+func TestGivesCheck_DirectOnly(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.Black, 4, 7)
+	queenField := setFigure(&chessBoard, board.Queen, board.White, 0, 0)
+
+	move := board.MakeMove(queenField, chessBoard.GetField(board.Cords{Col: 0, Row: 7}), board.EmptyType)
+
+	assert.True(t, chessBoard.GivesCheck(move))
+	assert.False(t, chessBoard.GivesDiscoveredCheck(move))
+}
+
+func TestKingIsNotAttackedAfterMove_PinnedFigureMovesAlongPin(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.White, 4, 0)
+	rookField := setFigure(&chessBoard, board.Rook, board.White, 4, 2)
+	setFigure(&chessBoard, board.Rook, board.Black, 4, 6)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+
+	alongPin := board.MakeMove(rookField, chessBoard.GetField(board.Cords{Col: 4, Row: 6}), board.EmptyType)
+	offPin := board.MakeMove(rookField, chessBoard.GetField(board.Cords{Col: 0, Row: 2}), board.EmptyType)
+
+	assert.NoError(t, validator.Validate(&chessBoard, alongPin))
+	assert.Error(t, validator.Validate(&chessBoard, offPin))
+}
+
+func TestKingIsNotAttackedAfterMove_EnPassantOpensRank(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.White, 0, 4)
+	whitePawnField := setFigure(&chessBoard, board.Pawn, board.White, 1, 4)
+	setFigure(&chessBoard, board.Rook, board.Black, 7, 4)
+	blackPawnField := setFigure(&chessBoard, board.Pawn, board.Black, 2, 6)
+	chessBoard.SetSideToMove(board.Black)
+	chessBoard = chessBoard.Move(board.MakeMove(
+		blackPawnField,
+		chessBoard.GetField(board.Cords{Col: 2, Row: 4}),
+		board.EmptyType,
+	))
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+
+	enPassant := board.MakeMove(whitePawnField, chessBoard.GetField(board.Cords{Col: 2, Row: 5}), board.EmptyType)
+
+	assertValidationError(t, validator.Validate(&chessBoard, enPassant), "KingIsNotAttackedAfterMoveValidator", board.KingAttackedCode)
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func pieceMobility(mobility []board.PieceMobility) map[board.Cords]int {
+	moves := make(map[board.Cords]int, len(mobility))
+	for _, piece := range mobility {
+		moves[piece.Field.Cords] = piece.Moves
+	}
+	return moves
+}
+
+func TestAttackMap_InitialPosition(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	attackMap := chessBoard.AttackMap()
+
+	f3 := attackMap.Square(cords("f3"), board.White)
+	assert.Equal(t, 3, f3.Count())
+	assert.ElementsMatch(t, []board.Cords{cords("e2"), cords("g2"), cords("g1")}, f3.Attackers)
+	assert.Equal(t, 2, f3.ByType[board.Pawn])
+	assert.Equal(t, 1, f3.ByType[board.Knight])
+	assert.Equal(t, 0, attackMap.Square(cords("f3"), board.Black).Count())
+
+	d7 := attackMap.Square(cords("d7"), board.Black)
+	assert.Equal(t, 4, d7.Count())
+	assert.Equal(t, [board.Queen + 1]int{board.King: 1, board.Queen: 1, board.Bishop: 1, board.Knight: 1}, d7.ByType)
+	assert.Equal(t, 0, attackMap.Square(cords("e4"), board.White).Count())
+	assert.Len(t, attackMap.Pieces(board.White), 16)
+}
+
+func TestAttackMap_SlidersStopAtFirstFigure(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	rook := setFigure(&chessBoard, board.Rook, board.White, 3, 3)
+	setFigure(&chessBoard, board.Pawn, board.White, 3, 5)
+	setFigure(&chessBoard, board.Knight, board.Black, 5, 3)
+	attackMap := chessBoard.AttackMap()
+
+	var rookAttacks []board.Cords
+	for _, piece := range attackMap.Pieces(board.White) {
+		if piece.Field.Cords == rook.Cords {
+			rookAttacks = piece.Attacked
+		}
+	}
+	assert.ElementsMatch(t, []board.Cords{
+		cords("d5"), cords("d6"),
+		cords("e4"), cords("f4"),
+		cords("d3"), cords("d2"), cords("d1"),
+		cords("c4"), cords("b4"), cords("a4"),
+	}, rookAttacks)
+	assert.Equal(t, 1, attackMap.Square(cords("d6"), board.White).ByType[board.Rook])
+	assert.Equal(t, 0, attackMap.Square(cords("d7"), board.White).ByType[board.Rook])
+	assert.Equal(t, 0, attackMap.Square(cords("g4"), board.White).Count())
+}
+
+func TestMobility_InitialPosition(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+
+	for _, side := range []board.FigureSide{board.White, board.Black} {
+		mobility := chessBoard.Mobility(side)
+		assert.Len(t, mobility, 16)
+		total := 0
+		for _, piece := range mobility {
+			total += piece.Moves
+		}
+		assert.Equal(t, 20, total)
+	}
+	moves := pieceMobility(chessBoard.Mobility(board.White))
+	assert.Equal(t, 2, moves[cords("g1")])
+	assert.Equal(t, 2, moves[cords("e2")])
+	assert.Equal(t, 0, moves[cords("d1")])
+}
+
+func TestMobility_PinnedFigure(t *testing.T) {
+	chessBoard, err := board.ParseFEN("4k3/4r3/8/8/8/8/4N3/4K2R b K - 0 1")
+	assert.NoError(t, err)
+
+	moves := pieceMobility(chessBoard.Mobility(board.White))
+	assert.Equal(t, 0, moves[cords("e2")])
+	assert.Equal(t, 5, moves[cords("e1")])
+	assert.Equal(t, 9, moves[cords("h1")])
+}
+
+func TestMobility_PawnOnLastRow(t *testing.T) {
+	chessBoard, err := board.ParseFEN("P3k3/8/8/8/8/8/8/4K3 w - - 0 1")
+	assert.NoError(t, err)
+
+	moves := pieceMobility(chessBoard.Mobility(board.White))
+	assert.Equal(t, 0, moves[cords("a8")])
+	assert.Equal(t, 5, moves[cords("e1")])
+	assert.Len(t, chessBoard.Mobility(board.Black), 1)
+}
@@ -0,0 +1,174 @@
+package test
+
+import (
+	"chess/bitboard"
+	"chess/board"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func sortedBoardMoves(moves []board.Move) []string {
+	notations := make([]string, 0, len(moves))
+	for _, move := range moves {
+		notations = append(notations, move.String())
+	}
+	sort.Strings(notations)
+	return notations
+}
+
+func sortedBitboardMoves(moves []bitboard.Move) []string {
+	notations := make([]string, 0, len(moves))
+	for _, move := range moves {
+		notations = append(notations, move.String())
+	}
+	sort.Strings(notations)
+	return notations
+}
+
+func bitboardPerft(position bitboard.Position, depth int) int {
+	moves := position.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+	nodes := 0
+	for _, move := range moves {
+		nodes += bitboardPerft(position.Move(move), depth-1)
+	}
+	return nodes
+}
+
+func middlegameBoard(t testing.TB) *board.Board {
+	chessSession := session.MakeDefaultSession()
+	for _, move := range []string{"e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6", "d2d3", "f8c5", "e1g1", "d7d6"} {
+		assert.NoError(t, chessSession.Move(moveRequest(move)))
+	}
+	return chessSession.ActualBoard
+}
+
+func TestBitboard_SquareConversion(t *testing.T) {
+	assert.Equal(t, bitboard.Square(0), bitboard.SquareOf(cords("a1")))
+	assert.Equal(t, bitboard.Square(63), bitboard.SquareOf(cords("h8")))
+	assert.Equal(t, cords("e4"), bitboard.SquareOf(cords("e4")).Cords())
+	assert.Equal(t, "e4", bitboard.SquareOf(cords("e4")).String())
+}
+
+func TestBitboard_FromBoard(t *testing.T) {
+	position := bitboard.FromBoard(board.InitDefaultBoard())
+
+	assert.Equal(t, bitboard.Bitboard(0xffff00000000ffff), position.All)
+	assert.Equal(t, bitboard.Bitboard(0xff00), position.Pieces[board.White][board.Pawn])
+	assert.Equal(t, bitboard.SquareOf(cords("e8")), position.King(board.Black))
+	assert.Equal(t, board.CastlingRights{WhiteShort: true, WhiteLong: true, BlackShort: true, BlackLong: true}, position.Castling)
+	assert.Equal(t, bitboard.NoSquare, position.EnPassant)
+	figure, filled := position.FigureAt(bitboard.SquareOf(cords("d1")))
+	assert.True(t, filled)
+	assert.Equal(t, board.Figure{FigureType: board.Queen, FigureSide: board.White}, figure)
+}
+
+func TestBitboard_ToBoardKeepsPosition(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "g8f6", "e4e5", "d7d5", "e1e2", "b8c6")
+	position := bitboard.FromBoard(chessSession.ActualBoard)
+
+	restored := position.ToBoard()
+
+	assert.True(t, restored.SamePosition(chessSession.ActualBoard))
+	assert.Equal(t, chessSession.ActualBoard.CastlingRights(), restored.CastlingRights())
+	assert.Equal(t, 2, restored.HalfmoveClock())
+	assert.Equal(t, 4, restored.FullmoveNumber())
+	assert.Equal(t, position, bitboard.FromBoard(&restored))
+}
+
+func TestBitboard_ToBoardKeepsEnPassant(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "a7a6", "e4e5", "d7d5")
+	position := bitboard.FromBoard(chessSession.ActualBoard)
+
+	restored := position.ToBoard()
+	restoredSession := session.MakeUncheckedSession(&restored)
+
+	assert.Equal(t, bitboard.SquareOf(cords("d6")), position.EnPassant)
+	assert.Equal(t, cords("d6"), *restored.EnPassantCords())
+	playMoves(t, &restoredSession, "e5d6")
+	assert.False(t, restoredSession.ActualBoard.GetField(cords("d5")).Filled)
+}
+
+func TestBitboard_Perft(t *testing.T) {
+	position := bitboard.FromBoard(board.InitDefaultBoard())
+
+	assert.Equal(t, 20, bitboardPerft(position, 1))
+	assert.Equal(t, 400, bitboardPerft(position, 2))
+	assert.Equal(t, 8902, bitboardPerft(position, 3))
+	assert.Equal(t, 197281, bitboardPerft(position, 4))
+}
+
+func TestBitboard_AgreesWithBoardOnRandomGames(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	for game := 0; game < 10; game++ {
+		chessBoard := board.InitDefaultBoard()
+		position := bitboard.FromBoard(chessBoard)
+		for ply := 0; ply < 100; ply++ {
+			boardMoves := generator.LegalMoves(*chessBoard)
+			bitboardMoves := position.LegalMoves()
+			if !assert.Equal(t, sortedBoardMoves(boardMoves), sortedBitboardMoves(bitboardMoves), "game %d ply %d", game, ply) ||
+				len(bitboardMoves) == 0 {
+				break
+			}
+			move := bitboardMoves[random.Intn(len(bitboardMoves))]
+			nextBoard := chessBoard.Move(move.BoardMove(chessBoard))
+			chessBoard = &nextBoard
+			position = position.Move(move)
+			assert.Equal(t, bitboard.FromBoard(chessBoard), position, "game %d ply %d", game, ply)
+			for square := bitboard.Square(0); square < 64; square++ {
+				for _, side := range []board.FigureSide{board.White, board.Black} {
+					assert.Equal(t,
+						chessBoard.IsFieldAttackedByOpposedSide(square.Cords(), side.Opposite()),
+						position.IsAttacked(square, side))
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkLegalMoves_Board(b *testing.B) {
+	chessBoard := middlegameBoard(b)
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		generator.LegalMoves(*chessBoard)
+	}
+}
+
+func BenchmarkLegalMoves_Bitboard(b *testing.B) {
+	position := bitboard.FromBoard(middlegameBoard(b))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		position.LegalMoves()
+	}
+}
+
+func BenchmarkIsAttacked_Board(b *testing.B) {
+	chessBoard := middlegameBoard(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for row := 0; row < board.ChessboardSize; row++ {
+			for col := 0; col < board.ChessboardSize; col++ {
+				chessBoard.IsFieldAttackedByOpposedSide(board.Cords{Col: col, Row: row}, board.White)
+			}
+		}
+	}
+}
+
+func BenchmarkIsAttacked_Bitboard(b *testing.B) {
+	position := bitboard.FromBoard(middlegameBoard(b))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for square := bitboard.Square(0); square < 64; square++ {
+			position.IsAttacked(square, board.Black)
+		}
+	}
+}
@@ -102,11 +102,11 @@ func TestSessionMove_FailSecondWhiteMove(t *testing.T) {
 	secondWhiteMoveDepartureCords := board.Cords{Col: 1, Row: 1}
 	secondWhiteMoveDestinationCords := board.Cords{Col: 1, Row: 3}
 
-	firstWhiteMoveIsMoved := chessSession.Move(session.MoveRequest{
+	firstWhiteMoveIsMoved := chessSession.TryMove(session.MoveRequest{
 		DepartureCords:   firstWhiteMoveDepartureCords,
 		DestinationCords: firstWhiteMoveDestinationCords,
 	})
-	secondWhiteMoveIsMoved := chessSession.Move(session.MoveRequest{
+	secondWhiteMoveIsMoved := chessSession.TryMove(session.MoveRequest{
 		DepartureCords:   secondWhiteMoveDepartureCords,
 		DestinationCords: secondWhiteMoveDestinationCords,
 	})
@@ -121,7 +121,7 @@ func TestSessionMove_FailFirstBlackMove(t *testing.T) {
 	firstBlackMoveDepartureCords := board.Cords{Col: 0, Row: 6}
 	firstBlackMoveDestinationCords := board.Cords{Col: 0, Row: 4}
 
-	firstBlackMoveIsMoved := chessSession.Move(session.MoveRequest{
+	firstBlackMoveIsMoved := chessSession.TryMove(session.MoveRequest{
 		DepartureCords:   firstBlackMoveDepartureCords,
 		DestinationCords: firstBlackMoveDestinationCords,
 	})
@@ -137,11 +137,11 @@ func TestSessionMove_SecondBlackMove(t *testing.T) {
 	blackMoveDepartureCords := board.Cords{Col: 0, Row: 6}
 	blackMoveDestinationCords := board.Cords{Col: 0, Row: 4}
 
-	whiteMoveIsMoved := chessSession.Move(session.MoveRequest{
+	whiteMoveIsMoved := chessSession.TryMove(session.MoveRequest{
 		DepartureCords:   whiteMoveDepartureCords,
 		DestinationCords: whiteMoveDestinationCords,
 	})
-	blackMoveIsMoved := chessSession.Move(session.MoveRequest{
+	blackMoveIsMoved := chessSession.TryMove(session.MoveRequest{
 		DepartureCords:   blackMoveDepartureCords,
 		DestinationCords: blackMoveDestinationCords,
 	})
@@ -164,3 +164,65 @@ func TestSessionMove_SecondBlackMove(t *testing.T) {
 	assert.True(t, blackDestination.Filled)
 	assert.Equal(t, blackDestination.Figure, board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: true})
 }
+
+func TestMakeBoard_WhiteToMove(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	assert.Equal(t, board.White, chessBoard.SideToMove())
+	assert.Equal(t, board.White, board.InitDefaultBoard().SideToMove())
+}
+
+func TestBoardMove_FlipsSideToMove(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	departure := chessBoard.GetField(board.Cords{Col: 4, Row: 1})
+	destination := chessBoard.GetField(board.Cords{Col: 4, Row: 3})
+
+	afterWhiteMove := chessBoard.Move(board.MakeMove(departure, destination, board.EmptyType))
+	assert.Equal(t, board.Black, afterWhiteMove.SideToMove())
+	assert.Equal(t, board.White, chessBoard.SideToMove())
+
+	departure = afterWhiteMove.GetField(board.Cords{Col: 4, Row: 6})
+	destination = afterWhiteMove.GetField(board.Cords{Col: 4, Row: 4})
+	afterBlackMove := afterWhiteMove.Move(board.MakeMove(departure, destination, board.EmptyType))
+	assert.Equal(t, board.White, afterBlackMove.SideToMove())
+}
+
+func TestCopyBoard_KeepsSideToMove(t *testing.T) {
+	b1 := board.MakeBoard()
+	b1.SetSideToMove(board.Black)
+	b2 := b1.Copy()
+	assert.Equal(t, board.Black, b2.SideToMove())
+}
+
+func TestCopyBoard_KeepsLastMove(t *testing.T) {
+	b1, err := board.ParseFEN("4k3/8/8/8/4Pp2/8/8/4K3 b - e3 0 1")
+	assert.NoError(t, err)
+	b2 := b1.Copy()
+
+	assert.Equal(t, b1.GetLastMove(), b2.GetLastMove())
+	assert.Equal(t, board.Cords{Col: 4, Row: 2}, *b2.EnPassantCords())
+	assert.True(t, b1.SamePosition(&b2))
+	assert.Equal(t, b1.Hash(), b2.Hash())
+	enPassant := board.MakeMove(b2.GetField(cords("f4")), b2.GetField(cords("e3")), board.EmptyType)
+	assert.True(t, board.MakeMoveGenerator(board.InitValidators()).IsValidMove(&b2, enPassant))
+}
+
+func TestSessionMove_BlackStarts(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	chessBoard.SetSideToMove(board.Black)
+	chessSession, err := session.MakeSession(chessBoard)
+	assert.NoError(t, err)
+
+	whiteMoveIsMoved := chessSession.TryMove(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 0, Row: 1},
+		DestinationCords: board.Cords{Col: 0, Row: 3},
+	})
+	blackMoveIsMoved := chessSession.TryMove(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 0, Row: 6},
+		DestinationCords: board.Cords{Col: 0, Row: 4},
+	})
+
+	assert.False(t, whiteMoveIsMoved)
+	assert.True(t, blackMoveIsMoved)
+	assert.Equal(t, board.White, chessSession.SideToMove())
+	assert.Len(t, chessSession.BoardHistory, 1)
+}
@@ -48,13 +48,13 @@ func TestLongCastleMove(t *testing.T) {
 	castleCords := board.Cords{Col: 2, Row: 0}
 	futureRookCords := board.Cords{Col: 3, Row: 0}
 
-	gameSession := session.MakeSession(&chessBoard)
+	gameSession := session.MakeUncheckedSession(&chessBoard)
 	moveRequest := session.MoveRequest{
 		DepartureCords:   whiteKingCords,
 		DestinationCords: castleCords,
 		PromoteToType:    board.EmptyType,
 	}
-	isMoved := gameSession.Move(moveRequest)
+	isMoved := gameSession.TryMove(moveRequest)
 
 	actualBoard := gameSession.ActualBoard
 
@@ -91,13 +91,13 @@ func TestShortCastleMove(t *testing.T) {
 	castleCords := board.Cords{Col: 6, Row: 0}
 	futureRookCords := board.Cords{Col: 5, Row: 0}
 
-	gameSession := session.MakeSession(&chessBoard)
+	gameSession := session.MakeUncheckedSession(&chessBoard)
 	moveRequest := session.MoveRequest{
 		DepartureCords:   whiteKingCords,
 		DestinationCords: castleCords,
 		PromoteToType:    board.EmptyType,
 	}
-	isMoved := gameSession.Move(moveRequest)
+	isMoved := gameSession.TryMove(moveRequest)
 
 	actualBoard := gameSession.ActualBoard
 
@@ -131,11 +131,11 @@ func TestWhiteLongCastleValidation(t *testing.T) {
 	whiteRookCords := board.Cords{Col: 0, Row: 0}
 	whiteRookField := board.Field{Figure: whiteRook, Cords: whiteRookCords, Filled: true}
 	chessBoard.SetField(whiteRookField)
-	castlingMoveValidator := board.CastlingMoveValidator{ActualBoard: &chessBoard}
+	castlingMoveValidator := board.CastlingMoveValidator{}
 	destinationCastleField := chessBoard.GetField(board.Cords{Col: 2, Row: 0})
 	castlingMove := board.MakeMove(whiteKingField, destinationCastleField, board.EmptyType)
 
-	isCastled := castlingMoveValidator.Validate(castlingMove)
+	isCastled := castlingMoveValidator.Validate(&chessBoard, castlingMove) == nil
 
 	assert.True(t, isCastled)
 }
@@ -150,11 +150,11 @@ func TestBlackLongCastleValidation(t *testing.T) {
 	blackRookCords := board.Cords{Col: 0, Row: 7}
 	blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 	chessBoard.SetField(blackRookField)
-	castlingMoveValidator := board.CastlingMoveValidator{ActualBoard: &chessBoard}
+	castlingMoveValidator := board.CastlingMoveValidator{}
 	destinationCastleField := chessBoard.GetField(board.Cords{Col: 2, Row: 7})
 	castlingMove := board.MakeMove(blackKingField, destinationCastleField, board.EmptyType)
 
-	isCastled := castlingMoveValidator.Validate(castlingMove)
+	isCastled := castlingMoveValidator.Validate(&chessBoard, castlingMove) == nil
 
 	assert.True(t, isCastled)
 }
@@ -174,11 +174,11 @@ func TestWhiteLongCastleValidation_FailKnightFilledBetween(t *testing.T) {
 	whiteRookCords := board.Cords{Col: 0, Row: 0}
 	whiteRookField := board.Field{Figure: whiteRook, Cords: whiteRookCords, Filled: true}
 	chessBoard.SetField(whiteRookField)
-	castlingMoveValidator := board.CastlingMoveValidator{ActualBoard: &chessBoard}
+	castlingMoveValidator := board.CastlingMoveValidator{}
 	destinationCastleField := chessBoard.GetField(board.Cords{Col: 2, Row: 0})
 	castlingMove := board.MakeMove(whiteKingField, destinationCastleField, board.EmptyType)
 
-	isCastled := castlingMoveValidator.Validate(castlingMove)
+	isCastled := castlingMoveValidator.Validate(&chessBoard, castlingMove) == nil
 
 	assert.False(t, isCastled)
 }
@@ -193,11 +193,11 @@ func TestBlackLongCastleValidation_FailKingMovedBefore(t *testing.T) {
 	blackRookCords := board.Cords{Col: 0, Row: 7}
 	blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 	chessBoard.SetField(blackRookField)
-	castlingMoveValidator := board.CastlingMoveValidator{ActualBoard: &chessBoard}
+	castlingMoveValidator := board.CastlingMoveValidator{}
 	destinationCastleField := chessBoard.GetField(board.Cords{Col: 2, Row: 7})
 	castlingMove := board.MakeMove(blackKingField, destinationCastleField, board.EmptyType)
 
-	isCastled := castlingMoveValidator.Validate(castlingMove)
+	isCastled := castlingMoveValidator.Validate(&chessBoard, castlingMove) == nil
 
 	assert.False(t, isCastled)
 }
@@ -212,11 +212,11 @@ func TestBlackLongCastleValidation_FailRookMovedBefore(t *testing.T) {
 	blackRookCords := board.Cords{Col: 0, Row: 7}
 	blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 	chessBoard.SetField(blackRookField)
-	castlingMoveValidator := board.CastlingMoveValidator{ActualBoard: &chessBoard}
+	castlingMoveValidator := board.CastlingMoveValidator{}
 	destinationCastleField := chessBoard.GetField(board.Cords{Col: 2, Row: 7})
 	castlingMove := board.MakeMove(blackKingField, destinationCastleField, board.EmptyType)
 
-	isCastled := castlingMoveValidator.Validate(castlingMove)
+	isCastled := castlingMoveValidator.Validate(&chessBoard, castlingMove) == nil
 
 	assert.False(t, isCastled)
 }
@@ -0,0 +1,287 @@
+package test
+
+import (
+	"chess/board"
+	"chess/clock"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeTimeSource struct {
+	now time.Time
+}
+
+func (timeSource *fakeTimeSource) Now() time.Time {
+	return timeSource.now
+}
+
+func (timeSource *fakeTimeSource) advance(duration time.Duration) {
+	timeSource.now = timeSource.now.Add(duration)
+}
+
+func makeFakeTimeSource() *fakeTimeSource {
+	return &fakeTimeSource{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func makeClock(t *testing.T, control clock.TimeControl, timeSource clock.TimeSource) *clock.Clock {
+	gameClock, err := clock.MakeClock(control, timeSource)
+	assert.NoError(t, err)
+	return gameClock
+}
+
+func TestClock_InvalidControl(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+
+	_, err := clock.MakeClock(clock.TimeControl{}, timeSource)
+	assert.ErrorIs(t, err, clock.ErrNoStages)
+	_, err = clock.MakeClock(clock.SuddenDeath(-time.Minute), timeSource)
+	assert.ErrorIs(t, err, clock.ErrNegativeTime)
+	_, err = clock.MakeClock(clock.TimeControl{Stages: []clock.Stage{
+		{Moves: 40, Time: time.Hour},
+		{Time: -time.Minute},
+	}}, timeSource)
+	assert.ErrorIs(t, err, clock.ErrNegativeTime)
+	_, err = clock.MakeClock(clock.Fischer(time.Minute, -time.Second), timeSource)
+	assert.ErrorIs(t, err, clock.ErrNegativeTime)
+}
+
+func TestClock_SuddenDeath(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	gameClock := makeClock(t, clock.SuddenDeath(5*time.Minute), timeSource)
+	gameClock.Start(board.White)
+
+	timeSource.advance(10 * time.Second)
+	assert.Equal(t, 4*time.Minute+50*time.Second, gameClock.Remaining(board.White))
+	assert.False(t, gameClock.Press(board.White))
+	timeSource.advance(20 * time.Second)
+
+	assert.Equal(t, 4*time.Minute+50*time.Second, gameClock.Remaining(board.White))
+	assert.Equal(t, 4*time.Minute+40*time.Second, gameClock.Remaining(board.Black))
+	assert.Equal(t, board.Black, gameClock.Running())
+	assert.Equal(t, 1, gameClock.MovesMade(board.White))
+}
+
+func TestClock_Fischer(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	gameClock := makeClock(t, clock.Fischer(3*time.Minute, 2*time.Second), timeSource)
+	gameClock.Start(board.White)
+
+	timeSource.advance(1 * time.Second)
+	gameClock.Press(board.White)
+
+	assert.Equal(t, 3*time.Minute+1*time.Second, gameClock.Remaining(board.White))
+}
+
+func TestClock_Bronstein(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	gameClock := makeClock(t, clock.BronsteinDelay(time.Minute, 5*time.Second), timeSource)
+	gameClock.Start(board.White)
+
+	timeSource.advance(3 * time.Second)
+	gameClock.Press(board.White)
+	timeSource.advance(20 * time.Second)
+	gameClock.Press(board.Black)
+
+	assert.Equal(t, time.Minute, gameClock.Remaining(board.White))
+	assert.Equal(t, 45*time.Second, gameClock.Remaining(board.Black))
+}
+
+func TestClock_USDelay(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	gameClock := makeClock(t, clock.SimpleDelay(time.Minute, 5*time.Second), timeSource)
+	gameClock.Start(board.White)
+
+	timeSource.advance(3 * time.Second)
+	assert.Equal(t, time.Minute, gameClock.Remaining(board.White))
+	gameClock.Press(board.White)
+	timeSource.advance(8 * time.Second)
+	assert.Equal(t, 57*time.Second, gameClock.Remaining(board.Black))
+	gameClock.Press(board.Black)
+
+	assert.Equal(t, time.Minute, gameClock.Remaining(board.White))
+	assert.Equal(t, 57*time.Second, gameClock.Remaining(board.Black))
+}
+
+func TestClock_MultiStage(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	control := clock.TimeControl{Stages: []clock.Stage{
+		{Moves: 40, Time: 90 * time.Minute, Increment: 30 * time.Second},
+		{Time: 30 * time.Minute, Increment: 30 * time.Second},
+	}}
+	gameClock := makeClock(t, control, timeSource)
+	gameClock.Start(board.White)
+
+	for move := 1; move <= 40; move++ {
+		timeSource.advance(time.Minute)
+		gameClock.Press(board.White)
+		timeSource.advance(time.Minute)
+		gameClock.Press(board.Black)
+		if move == 39 {
+			assert.Equal(t, 90*time.Minute-39*30*time.Second, gameClock.Remaining(board.White))
+		}
+	}
+
+	assert.Equal(t, 90*time.Minute-40*30*time.Second+30*time.Minute, gameClock.Remaining(board.White))
+	assert.Equal(t, 90*time.Minute-40*30*time.Second+30*time.Minute, gameClock.Remaining(board.Black))
+}
+
+func TestClock_FinalStageRepeats(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	control := clock.TimeControl{Stages: []clock.Stage{{Moves: 40, Time: 2 * time.Hour}}}
+	gameClock := makeClock(t, control, timeSource)
+	gameClock.Start(board.White)
+
+	for move := 1; move <= 100; move++ {
+		timeSource.advance(2 * time.Minute)
+		assert.False(t, gameClock.Press(board.White), "move %d", move)
+		gameClock.Press(board.Black)
+		switch move {
+		case 39:
+			assert.Equal(t, 2*time.Hour-39*2*time.Minute, gameClock.Remaining(board.White))
+		case 40:
+			assert.Equal(t, 4*time.Hour-40*2*time.Minute, gameClock.Remaining(board.White))
+		case 80:
+			assert.Equal(t, 6*time.Hour-80*2*time.Minute, gameClock.Remaining(board.White))
+		}
+	}
+	assert.Equal(t, 6*time.Hour-100*2*time.Minute, gameClock.Remaining(board.White))
+	assert.Equal(t, 6*time.Hour, gameClock.Remaining(board.Black))
+}
+
+func TestClock_PressAfterTimeRanOut(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	gameClock := makeClock(t, clock.Fischer(time.Minute, 10*time.Second), timeSource)
+	gameClock.Start(board.White)
+
+	timeSource.advance(61 * time.Second)
+
+	assert.True(t, gameClock.Press(board.White))
+	side, flagged := gameClock.Flagged()
+	assert.True(t, flagged)
+	assert.Equal(t, board.White, side)
+	assert.Equal(t, time.Duration(0), gameClock.Remaining(board.White))
+}
+
+func TestSessionClock_FlagFall(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessSession := session.MakeDefaultSession()
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+
+	timeSource.advance(10 * time.Second)
+	playMoves(t, &chessSession, "e2e4")
+	remaining, hasClock := chessSession.RemainingTime(board.White)
+	assert.True(t, hasClock)
+	assert.Equal(t, 50*time.Second, remaining)
+
+	timeSource.advance(time.Minute)
+	assert.True(t, chessSession.CheckFlag())
+	assert.Equal(t, session.WhiteWon, chessSession.Result())
+	assert.Equal(t, session.Timeout, chessSession.Termination())
+	assert.ErrorIs(t, chessSession.Move(session.MoveRequest{
+		DepartureCords:   cords("e7"),
+		DestinationCords: cords("e5"),
+	}), session.ErrGameOver)
+}
+
+func TestSessionClock_MoveAfterFlagFall(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessSession := session.MakeDefaultSession()
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+
+	timeSource.advance(2 * time.Minute)
+	err := chessSession.Move(session.MoveRequest{DepartureCords: cords("e2"), DestinationCords: cords("e4")})
+
+	assert.ErrorIs(t, err, session.ErrGameOver)
+	assert.Equal(t, session.BlackWon, chessSession.Result())
+	assert.Empty(t, chessSession.BoardHistory)
+}
+
+func TestSessionClock_FlagFallAgainstInsufficientMaterial(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Knight, board.Black, 5, 5)
+	chessSession, err := session.MakeSession(&chessBoard)
+	assert.NoError(t, err)
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+
+	timeSource.advance(2 * time.Minute)
+
+	assert.True(t, chessSession.CheckFlag())
+	assert.Equal(t, session.Draw, chessSession.Result())
+	assert.Equal(t, session.TimeoutVsInsufficientMaterial, chessSession.Termination())
+}
+
+func TestSessionClock_FlagFallAgainstKnightWithPawn(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Pawn, board.White, 3, 3)
+	setFigure(&chessBoard, board.Knight, board.Black, 5, 5)
+	chessSession, err := session.MakeSession(&chessBoard)
+	assert.NoError(t, err)
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+
+	timeSource.advance(2 * time.Minute)
+
+	assert.True(t, chessSession.CheckFlag())
+	assert.Equal(t, session.BlackWon, chessSession.Result())
+	assert.Equal(t, session.Timeout, chessSession.Termination())
+}
+
+func TestSessionClock_WithoutClock(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	_, hasClock := chessSession.RemainingTime(board.White)
+
+	assert.False(t, hasClock)
+	assert.False(t, chessSession.CheckFlag())
+}
+
+func TestHasMatingMaterial(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	assert.False(t, chessBoard.HasMatingMaterial(board.White))
+
+	setFigure(&chessBoard, board.Bishop, board.White, 2, 0)
+	setFigure(&chessBoard, board.Bishop, board.White, 4, 2)
+	assert.False(t, chessBoard.HasMatingMaterial(board.White))
+
+	setFigure(&chessBoard, board.Bishop, board.White, 5, 0)
+	assert.True(t, chessBoard.HasMatingMaterial(board.White))
+
+	chessBoard = makeKingsBoard()
+	setFigure(&chessBoard, board.Knight, board.Black, 1, 7)
+	assert.False(t, chessBoard.HasMatingMaterial(board.Black))
+	setFigure(&chessBoard, board.Pawn, board.Black, 1, 5)
+	assert.True(t, chessBoard.HasMatingMaterial(board.Black))
+}
+
+func TestHasMatingMaterial_KnightAgainstPawn(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Knight, board.White, 3, 3)
+	setFigure(&chessBoard, board.Pawn, board.Black, 6, 6)
+
+	assert.True(t, chessBoard.HasMatingMaterial(board.White))
+	assert.True(t, chessBoard.HasMatingMaterial(board.Black))
+}
+
+func TestHasMatingMaterial_BishopAgainstRook(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Bishop, board.White, 3, 3)
+	setFigure(&chessBoard, board.Rook, board.Black, 6, 6)
+
+	assert.True(t, chessBoard.HasMatingMaterial(board.White))
+	assert.True(t, chessBoard.HasMatingMaterial(board.Black))
+}
+
+func TestHasMatingMaterial_BishopsOnSameColorFields(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Bishop, board.White, 3, 3)
+	setFigure(&chessBoard, board.Bishop, board.Black, 5, 5)
+	assert.False(t, chessBoard.HasMatingMaterial(board.White))
+	assert.False(t, chessBoard.HasMatingMaterial(board.Black))
+
+	setFigure(&chessBoard, board.Bishop, board.Black, 5, 4)
+	assert.True(t, chessBoard.HasMatingMaterial(board.White))
+	assert.True(t, chessBoard.HasMatingMaterial(board.Black))
+}
@@ -0,0 +1,176 @@
+package test
+
+import (
+	"chess/board"
+	"chess/evaluation"
+	"chess/search"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// mirrorFEN swaps the colors of the position, so it has to evaluate the same for the side to move
+func mirrorFEN(fen string) string {
+	fields := strings.Fields(fen)
+	rows := strings.Split(fields[0], "/")
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	swapCase := func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' {
+				return r - 'a' + 'A'
+			} else if r >= 'A' && r <= 'Z' {
+				return r - 'A' + 'a'
+			}
+			return r
+		}, value)
+	}
+	fields[0] = swapCase(strings.Join(rows, "/"))
+	fields[1] = map[string]string{"w": "b", "b": "w"}[fields[1]]
+	if fields[2] != "-" {
+		fields[2] = swapCase(fields[2])
+	}
+	if fields[3] != "-" {
+		fields[3] = fields[3][:1] + map[byte]string{'3': "6", '6': "3"}[fields[3][1]]
+	}
+	return strings.Join(fields, " ")
+}
+
+func evaluationTrace(t *testing.T, fen string) evaluation.Trace {
+	position, err := board.ParseFEN(fen)
+	assert.NoError(t, err, fen)
+	return evaluation.MakeEvaluator(evaluation.DefaultWeights()).Trace(position)
+}
+
+func TestEvaluate_InitialPositionIsEqual(t *testing.T) {
+	trace := evaluationTrace(t, board.DefaultFEN)
+
+	assert.Equal(t, 0, trace.Score)
+	assert.Equal(t, trace.MaxPhase, trace.Phase)
+	assert.Equal(t, 0, evaluation.Evaluate(board.InitDefaultBoard()))
+}
+
+func TestEvaluate_ColorSymmetry(t *testing.T) {
+	for _, fen := range []string{
+		"r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3",
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+	} {
+		trace := evaluationTrace(t, fen)
+		mirrored := evaluationTrace(t, mirrorFEN(fen))
+		assert.Equal(t, trace.Score, mirrored.Score, fen)
+		for _, term := range evaluation.Terms() {
+			assert.Equal(t, trace.Terms[term].White, mirrored.Terms[term].Black, "%s %s", fen, term)
+		}
+	}
+}
+
+func TestEvaluate_SideToMovePerspective(t *testing.T) {
+	white := evaluationTrace(t, "4k3/8/8/8/8/8/8/3QK3 w - - 0 1")
+	black := evaluationTrace(t, "4k3/8/8/8/8/8/8/3QK3 b - - 0 1")
+
+	assert.Greater(t, white.Score, 800)
+	assert.Equal(t, -white.Score, black.Score)
+}
+
+func TestEvaluate_TraceSumsUpTerms(t *testing.T) {
+	trace := evaluationTrace(t, "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+
+	sum := 0
+	for _, term := range evaluation.Terms() {
+		sum += trace.Terms[term].Value
+	}
+	assert.Equal(t, trace.Score, sum)
+	assert.Equal(t, "pawn structure", evaluation.PawnStructureTerm.String())
+}
+
+func TestEvaluate_PawnStructure(t *testing.T) {
+	weights := evaluation.DefaultWeights()
+
+	trace := evaluationTrace(t, "4k3/p7/8/8/8/P7/P7/4K3 w - - 0 1")
+	assert.Equal(t, evaluation.Score{Middlegame: -30, Endgame: -50}, trace.Terms[evaluation.PawnStructureTerm].White)
+	assert.Equal(t, weights.IsolatedPawn, trace.Terms[evaluation.PawnStructureTerm].Black)
+
+	trace = evaluationTrace(t, "4k3/8/8/3P4/8/8/8/4K3 w - - 0 1")
+	assert.Equal(t, evaluation.Score{Middlegame: 15, Endgame: 45}, trace.Terms[evaluation.PawnStructureTerm].White)
+
+	trace = evaluationTrace(t, "4k3/8/2p5/8/3P4/8/8/4K3 w - - 0 1")
+	assert.Equal(t, weights.IsolatedPawn, trace.Terms[evaluation.PawnStructureTerm].White)
+	assert.Equal(t, weights.IsolatedPawn, trace.Terms[evaluation.PawnStructureTerm].Black)
+}
+
+func TestEvaluate_KingSafety(t *testing.T) {
+	sheltered := evaluationTrace(t, "4k3/8/8/8/8/8/5PPP/6K1 w - - 0 1")
+	exposed := evaluationTrace(t, "4k3/8/8/8/5PPP/8/8/6K1 w - - 0 1")
+	attacked := evaluationTrace(t, "4k3/8/8/8/8/8/5PPP/3r2K1 w - - 0 1")
+
+	shelter := evaluation.DefaultWeights().KingShield
+	assert.Equal(t, shelter.Middlegame*3, sheltered.Terms[evaluation.KingSafetyTerm].White.Middlegame)
+	assert.Equal(t, 0, exposed.Terms[evaluation.KingSafetyTerm].White.Middlegame)
+	assert.Less(t, attacked.Terms[evaluation.KingSafetyTerm].White.Middlegame, sheltered.Terms[evaluation.KingSafetyTerm].White.Middlegame)
+}
+
+func TestEvaluate_BishopPairAndMobility(t *testing.T) {
+	trace := evaluationTrace(t, "4k3/8/8/8/8/8/8/2B1KB2 w - - 0 1")
+	assert.Equal(t, evaluation.DefaultWeights().BishopPair, trace.Terms[evaluation.BishopPairTerm].White)
+	assert.Equal(t, evaluation.Score{}, trace.Terms[evaluation.BishopPairTerm].Black)
+
+	free := evaluationTrace(t, "4k3/8/8/8/3N4/8/8/4K3 w - - 0 1")
+	cornered := evaluationTrace(t, "4k3/8/8/8/8/8/8/N3K3 w - - 0 1")
+	assert.Equal(t, evaluation.Score{Middlegame: 32, Endgame: 32}, free.Terms[evaluation.MobilityTerm].White)
+	assert.Equal(t, evaluation.Score{Middlegame: 8, Endgame: 8}, cornered.Terms[evaluation.MobilityTerm].White)
+}
+
+func TestEvaluate_TaperedByPhase(t *testing.T) {
+	trace := evaluationTrace(t, "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1")
+
+	assert.Equal(t, 0, trace.Phase)
+	material := trace.Terms[evaluation.MaterialTerm]
+	assert.Equal(t, material.White.Endgame, material.Value)
+}
+
+func TestEvaluate_CustomWeights(t *testing.T) {
+	var weights evaluation.Weights
+	weights.Material[board.Knight] = evaluation.Score{Middlegame: 300, Endgame: 300}
+	position, err := board.ParseFEN("4k3/8/8/8/3N4/8/8/4K3 b - - 0 1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, -300, evaluation.MakeEvaluator(weights).Evaluate(position))
+}
+
+func TestEvaluate_UsedBySearch(t *testing.T) {
+	position, err := board.ParseFEN("6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1")
+	assert.NoError(t, err)
+	result, err := search.MakeSearcher(evaluation.Evaluate).Search(context.Background(), position, search.Limits{Depth: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("a1a8"), result.Move)
+	assert.Equal(t, 1, search.MateIn(result.Score))
+}
+
+func TestEvaluate_MatchesTraceWithoutAllocating(t *testing.T) {
+	evaluator := evaluation.MakeEvaluator(evaluation.DefaultWeights())
+	for _, fen := range []string{
+		board.DefaultFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R b KQ - 1 8",
+		"4k3/8/8/8/8/8/5PPP/3r2K1 w - - 0 1",
+	} {
+		position, err := board.ParseFEN(fen)
+		assert.NoError(t, err, fen)
+		assert.Equal(t, evaluator.Trace(position).Score, evaluator.Evaluate(position), fen)
+		assert.Zero(t, testing.AllocsPerRun(10, func() { evaluator.Evaluate(position) }), fen)
+	}
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	position, _ := board.ParseFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		evaluation.Evaluate(position)
+	}
+}
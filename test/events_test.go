@@ -0,0 +1,190 @@
+package test
+
+import (
+	"chess/board"
+	"chess/clock"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func eventTypes(events []session.Event) []session.EventType {
+	types := make([]session.EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestSessionEvents_MoveAppliedAndRejected(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	var events []session.Event
+	chessSession.Subscribe(func(event session.Event) {
+		events = append(events, event)
+	})
+
+	playMoves(t, &chessSession, "e2e4")
+	request := session.MoveRequest{DepartureCords: cords("e7"), DestinationCords: cords("e4")}
+	assert.Error(t, chessSession.Move(request))
+
+	assert.Equal(t, []session.EventType{session.MoveAppliedEvent, session.MoveRejectedEvent}, eventTypes(events))
+	assert.Equal(t, "e4", events[0].Record.SAN)
+	assert.Equal(t, board.White, events[0].Side)
+	assert.Equal(t, request, events[1].Request)
+	assert.Equal(t, board.Black, events[1].Side)
+	assertValidationError(t, events[1].Err, "PawnMoveValidator", board.IllegalFigureMoveCode)
+}
+
+func TestSessionEvents_CheckmateFinishesGame(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	var events []session.Event
+	chessSession.Subscribe(func(event session.Event) {
+		events = append(events, event)
+	})
+
+	playMoves(t, &chessSession, "f2f3", "e7e5", "g2g4", "d8h4")
+
+	assert.Equal(t, []session.EventType{
+		session.MoveAppliedEvent, session.MoveAppliedEvent, session.MoveAppliedEvent,
+		session.MoveAppliedEvent, session.CheckEvent, session.GameOverEvent,
+	}, eventTypes(events))
+	assert.Equal(t, session.BlackWon, events[5].Result)
+	assert.Equal(t, session.Checkmate, events[5].Termination)
+}
+
+func TestSessionEvents_DrawOfferAndResignation(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	var events []session.Event
+	chessSession.Subscribe(func(event session.Event) {
+		events = append(events, event)
+	})
+
+	assert.NoError(t, chessSession.OfferDraw(board.White))
+	assert.NoError(t, chessSession.Resign(board.Black))
+
+	assert.Equal(t, []session.EventType{session.DrawOfferedEvent, session.GameOverEvent}, eventTypes(events))
+	assert.Equal(t, board.White, events[0].Side)
+	assert.Equal(t, session.WhiteWon, events[1].Result)
+	assert.Equal(t, session.Resignation, events[1].Termination)
+}
+
+func TestSessionEvents_Flag(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessSession := session.MakeDefaultSession()
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+	var events []session.Event
+	chessSession.Subscribe(func(event session.Event) {
+		events = append(events, event)
+	})
+
+	timeSource.advance(2 * time.Minute)
+	assert.True(t, chessSession.CheckFlag())
+
+	assert.Equal(t, []session.EventType{session.FlagEvent, session.GameOverEvent}, eventTypes(events))
+	assert.Equal(t, board.White, events[0].Side)
+	assert.Equal(t, session.Timeout, events[1].Termination)
+}
+
+func TestSessionEvents_Unsubscribe(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	first, second := 0, 0
+	unsubscribeFirst := chessSession.Subscribe(func(event session.Event) { first++ })
+	chessSession.Subscribe(func(event session.Event) { second++ })
+
+	playMoves(t, &chessSession, "e2e4")
+	unsubscribeFirst()
+	playMoves(t, &chessSession, "e7e5")
+
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 2, second)
+}
+
+func TestSessionEvents_Channel(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	events, unsubscribe := chessSession.Events(0)
+	received := make(chan []session.EventType)
+	go func() {
+		var types []session.EventType
+		for event := range events {
+			types = append(types, event.Type)
+		}
+		received <- types
+	}()
+
+	playMoves(t, &chessSession, "e2e4", "f7f6", "d1h5")
+	unsubscribe()
+	playMoves(t, &chessSession, "g7g6")
+
+	assert.Equal(t, []session.EventType{
+		session.MoveAppliedEvent, session.MoveAppliedEvent, session.MoveAppliedEvent, session.CheckEvent,
+	}, <-received)
+}
+
+func TestSessionEvents_ListenerUnsubscribesItself(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	calls := make(map[string]int)
+	chessSession.Subscribe(func(event session.Event) { calls["a"]++ })
+	var unsubscribeB func()
+	unsubscribeB = chessSession.Subscribe(func(event session.Event) {
+		calls["b"]++
+		unsubscribeB()
+	})
+	chessSession.Subscribe(func(event session.Event) { calls["c"]++ })
+
+	playMoves(t, &chessSession, "e2e4")
+	assert.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1}, calls)
+
+	playMoves(t, &chessSession, "e7e5")
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 2}, calls)
+}
+
+func TestSessionEvents_ListenerUnsubscribesLaterListener(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	calls := 0
+	var unsubscribeLater func()
+	chessSession.Subscribe(func(event session.Event) { unsubscribeLater() })
+	unsubscribeLater = chessSession.Subscribe(func(event session.Event) { calls++ })
+
+	playMoves(t, &chessSession, "e2e4")
+
+	assert.Equal(t, 0, calls)
+}
+
+func TestSessionEvents_ChannelClosedWhileSendIsBlocked(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	_, closeEvents := chessSession.Events(0)
+	moved := make(chan error)
+	go func() {
+		moved <- chessSession.Move(session.MoveRequest{DepartureCords: cords("e2"), DestinationCords: cords("e4")})
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.NotPanics(t, closeEvents)
+	assert.NoError(t, <-moved)
+	assert.NotPanics(t, closeEvents)
+}
+
+func TestSessionEvents_UndoRedoAndTakeback(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5")
+	var events []session.Event
+	chessSession.Subscribe(func(event session.Event) {
+		events = append(events, event)
+	})
+
+	assert.NoError(t, chessSession.Undo())
+	assert.NoError(t, chessSession.Redo())
+	assert.NoError(t, chessSession.RequestTakeback(board.White))
+	assert.NoError(t, chessSession.AcceptTakeback(board.Black))
+
+	assert.Equal(t, []session.EventType{
+		session.MoveUndoneEvent, session.MoveRedoneEvent,
+		session.MoveUndoneEvent, session.MoveUndoneEvent, session.TakebackAcceptedEvent,
+	}, eventTypes(events))
+	assert.Equal(t, "e5", events[0].Record.SAN)
+	assert.Equal(t, "e5", events[1].Record.SAN)
+	assert.Equal(t, "e5", events[2].Record.SAN)
+	assert.Equal(t, "e4", events[3].Record.SAN)
+	assert.Equal(t, board.Black, events[4].Side)
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"chess/board"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMoveHistory_MoveText(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "e2e4", "e7e5", "g1f3", "b8c6", "f1b5", "a7a6", "b5c6", "d7c6", "e1g1")
+
+	assert.Equal(t, "1. e4 e5 2. Nf3 Nc6 3. Bb5 a6 4. Bxc6 dxc6 5. O-O", chessSession.MoveText())
+	assert.Len(t, chessSession.MoveHistory, 9)
+	assert.Equal(t, board.Black, chessSession.MoveHistory[7].Side)
+	assert.Equal(t, 4, chessSession.MoveHistory[7].Number)
+}
+
+func TestMoveHistory_CapturedMaterial(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "e2e4", "d7d5", "e4d5", "d8d5", "b1c3", "d5a2", "a1a2")
+
+	assert.Equal(t, []board.FigureType{board.Pawn, board.Queen}, figureTypes(chessSession.CapturedFigures(board.White)))
+	assert.Equal(t, []board.FigureType{board.Pawn, board.Pawn}, figureTypes(chessSession.CapturedFigures(board.Black)))
+	assert.Equal(t, 10, chessSession.CapturedMaterial(board.White))
+	assert.Equal(t, 2, chessSession.CapturedMaterial(board.Black))
+	assert.Equal(t, 8, chessSession.MaterialBalance())
+	assert.Equal(t, "Rxa2", chessSession.MoveHistory[6].SAN)
+	assert.False(t, chessSession.MoveHistory[4].IsCapture())
+}
+
+func TestMoveHistory_Checkmate(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "e2e4", "f7f6", "d2d4", "g7g5", "d1h5")
+
+	assert.Equal(t, "1. e4 f6 2. d4 g5 3. Qh5#", chessSession.MoveText())
+	assert.True(t, chessSession.MoveHistory[4].Check)
+	assert.False(t, chessSession.MoveHistory[3].Check)
+}
+
+func TestMoveHistory_EnPassant(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "e2e4", "a7a6", "e4e5", "d7d5", "e5d6")
+
+	record := chessSession.MoveHistory[4]
+	assert.Equal(t, "exd6", record.SAN)
+	assert.Equal(t, board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: true}, record.Captured)
+}
+
+func TestMoveHistory_PromotionWithCheck(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Pawn, board.White, 4, 6)
+	chessSession := session.MakeUncheckedSession(&chessBoard)
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   cords("e7"),
+		DestinationCords: cords("e8"),
+		PromoteToType:    board.Queen,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1. e8=Q+", chessSession.MoveText())
+}
+
+func TestMoveHistory_Disambiguation(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Knight, board.White, 1, 0)
+	setFigure(&chessBoard, board.Knight, board.White, 5, 2)
+	setFigure(&chessBoard, board.Rook, board.White, 2, 2)
+	setFigure(&chessBoard, board.Rook, board.White, 2, 6)
+	setFigure(&chessBoard, board.Queen, board.White, 1, 2)
+	setFigure(&chessBoard, board.Queen, board.White, 3, 2)
+	setFigure(&chessBoard, board.Queen, board.White, 1, 4)
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	san := func(departure string, destination string) string {
+		move := board.MakeMove(chessBoard.GetField(cords(departure)), chessBoard.GetField(cords(destination)), board.EmptyType)
+		return generator.SAN(chessBoard, move)
+	}
+
+	assert.Equal(t, "Nbd2", san("b1", "d2"))
+	assert.Equal(t, "R3c5", san("c3", "c5"))
+	assert.Equal(t, "Qb3d5", san("b3", "d5"))
+	assert.Equal(t, "Nh4", san("f3", "h4"))
+}
+
+func TestMoveHistory_UndoRedo(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5")
+
+	assert.NoError(t, chessSession.Undo())
+	assert.Equal(t, "1. e4", chessSession.MoveText())
+
+	assert.NoError(t, chessSession.Redo())
+	assert.Equal(t, "1. e4 e5", chessSession.MoveText())
+
+	assert.NoError(t, chessSession.Undo())
+	playMoves(t, &chessSession, "c7c5")
+	assert.Equal(t, "1. e4 c5", chessSession.MoveText())
+}
+
+func TestMoveHistory_BlackStarts(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	chessBoard.SetSideToMove(board.Black)
+	chessSession := session.MakeUncheckedSession(chessBoard)
+
+	playMoves(t, &chessSession, "e7e5", "e2e4")
+
+	assert.Equal(t, "1... e5 2. e4", chessSession.MoveText())
+}
+
+func TestMoveHistory_Timestamps(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessSession := session.MakeDefaultSession()
+	chessSession.SetTimeSource(timeSource)
+
+	playMoves(t, &chessSession, "e2e4")
+	timeSource.advance(3 * time.Second)
+	playMoves(t, &chessSession, "e7e5")
+
+	assert.Equal(t, timeSource.Now(), chessSession.MoveHistory[1].Time)
+	assert.Equal(t, 3*time.Second, chessSession.MoveHistory[1].Time.Sub(chessSession.MoveHistory[0].Time))
+}
+
+func figureTypes(figures []board.Figure) []board.FigureType {
+	types := make([]board.FigureType, 0, len(figures))
+	for _, figure := range figures {
+		types = append(types, figure.FigureType)
+	}
+	return types
+}
@@ -92,8 +92,8 @@ func TestKingIsNotAttackedAfterKingMove(t *testing.T) {
 	destinationField := chessBoard.GetField(destinationCords)
 	kingMove := board.MakeMove(whiteKingField, destinationField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(kingMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, kingMove) != nil
 
 	assert.False(t, kingIsAttacked)
 }
@@ -115,8 +115,8 @@ func TestKingIsAttackedAfterKingMove_KingAlreadyAttacked(t *testing.T) {
 	destinationField := chessBoard.GetField(destinationCords)
 	kingMove := board.MakeMove(whiteKingField, destinationField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(kingMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, kingMove) != nil
 
 	assert.True(t, kingIsAttacked)
 }
@@ -138,8 +138,8 @@ func TestKingIsAttackedAfterKingMove_KingMovedToAttackedField(t *testing.T) {
 	destinationField := chessBoard.GetField(destinationCords)
 	kingMove := board.MakeMove(whiteKingField, destinationField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(kingMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, kingMove) != nil
 
 	assert.True(t, kingIsAttacked)
 }
@@ -167,8 +167,8 @@ func TestKingIsAttacked_CoveringFigureMoved(t *testing.T) {
 	destinationField := chessBoard.GetField(destinationCords)
 	bishopMove := board.MakeMove(whiteBishopField, destinationField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(bishopMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, bishopMove) != nil
 
 	assert.True(t, kingIsAttacked)
 }
@@ -193,8 +193,8 @@ func TestKingIsNotAttacked_KingAlreadyAttacked_AttackingFigureKilled(t *testing.
 
 	bishopMove := board.MakeMove(whiteBishopField, blackRookField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(bishopMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, bishopMove) != nil
 
 	assert.False(t, kingIsAttacked)
 }
@@ -224,8 +224,8 @@ func TestKingIsAttacked_KingAlreadyAttacked_AttackingFigureKilledByCoveringPiece
 
 	bishopMove := board.MakeMove(whiteBishopField, blackRookField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(bishopMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, bishopMove) != nil
 
 	assert.True(t, kingIsAttacked)
 }
@@ -252,8 +252,8 @@ func TestKingIsNotAttacked_KingAlreadyAttacked_KingCovered(t *testing.T) {
 	destinationField := chessBoard.GetField(destinationCords)
 	bishopMove := board.MakeMove(whiteBishopField, destinationField, board.EmptyType)
 
-	validator := board.KingIsNotAttackedAfterMoveValidator{ActualBoard: &chessBoard}
-	kingIsAttacked := !validator.Validate(bishopMove)
+	validator := board.KingIsNotAttackedAfterMoveValidator{}
+	kingIsAttacked := validator.Validate(&chessBoard, bishopMove) != nil
 
 	assert.False(t, kingIsAttacked)
 }
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"chess/bitboard"
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func naiveSlidingAttacks(square bitboard.Square, occupied bitboard.Bitboard, directions []board.Direction) bitboard.Bitboard {
+	var attacks bitboard.Bitboard
+	for _, direction := range directions {
+		cords := direction.Next(square.Cords())
+		for cords.Col >= 0 && cords.Col < 8 && cords.Row >= 0 && cords.Row < 8 {
+			target := bitboard.SquareOf(cords)
+			attacks |= bitboard.Of(target)
+			if occupied.Has(target) {
+				break
+			}
+			cords = direction.Next(cords)
+		}
+	}
+	return attacks
+}
+
+func squares(notations ...string) bitboard.Bitboard {
+	var set bitboard.Bitboard
+	for _, notation := range notations {
+		set |= bitboard.Of(bitboard.SquareOf(cords(notation)))
+	}
+	return set
+}
+
+func TestMagic_SlidersAgreeWithRays(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	for square := bitboard.Square(0); square < 64; square++ {
+		for i := 0; i < 200; i++ {
+			occupied := bitboard.Bitboard(random.Uint64() & random.Uint64())
+			assert.Equal(t, naiveSlidingAttacks(square, occupied, board.LineDirections), bitboard.RookAttacks(square, occupied))
+			assert.Equal(t, naiveSlidingAttacks(square, occupied, board.DiagonalDirections), bitboard.BishopAttacks(square, occupied))
+		}
+	}
+}
+
+func TestMagic_RookAttacks(t *testing.T) {
+	occupied := squares("d6", "b4", "d2", "g4")
+
+	attacks := bitboard.RookAttacks(bitboard.SquareOf(cords("d4")), occupied)
+
+	assert.Equal(t, squares("d5", "d6", "c4", "b4", "d3", "d2", "e4", "f4", "g4"), attacks)
+}
+
+func TestMagic_BishopAttacksFromCorner(t *testing.T) {
+	attacks := bitboard.BishopAttacks(bitboard.SquareOf(cords("a1")), squares("e5"))
+
+	assert.Equal(t, squares("b2", "c3", "d4", "e5"), attacks)
+	assert.Equal(t, squares("a2", "b1", "b2"), bitboard.QueenAttacks(bitboard.SquareOf(cords("a1")), ^bitboard.Bitboard(0)))
+}
+
+func TestAttackTables(t *testing.T) {
+	assert.Equal(t, squares("b3", "c2"), bitboard.KnightAttacks(bitboard.SquareOf(cords("a1"))))
+	assert.Equal(t, squares("g1", "g2", "h2"), bitboard.KingAttacks(bitboard.SquareOf(cords("h1"))))
+	assert.Equal(t, squares("d5", "f5"), bitboard.PawnAttacks(board.White, bitboard.SquareOf(cords("e4"))))
+	assert.Equal(t, squares("b6"), bitboard.PawnAttacks(board.Black, bitboard.SquareOf(cords("a7"))))
+}
+
+func TestBitboard_IsFieldAttackedByOpposedSide(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Rook, board.Black, 3, 7)
+	setFigure(&chessBoard, board.Pawn, board.White, 3, 3)
+	position := bitboard.FromBoard(&chessBoard)
+
+	assert.True(t, position.IsFieldAttackedByOpposedSide(cords("d5"), board.White))
+	assert.True(t, position.IsFieldAttackedByOpposedSide(cords("d4"), board.White))
+	assert.False(t, position.IsFieldAttackedByOpposedSide(cords("d3"), board.White))
+	assert.True(t, position.IsFieldAttackedByOpposedSide(cords("e5"), board.Black))
+}
+
+func BenchmarkRookAttacks(b *testing.B) {
+	occupied := squares("d6", "b4", "d2", "g4", "a1", "h8")
+	for i := 0; i < b.N; i++ {
+		for square := bitboard.Square(0); square < 64; square++ {
+			bitboard.RookAttacks(square, occupied)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func assertSameBoard(t *testing.T, expected *board.Board, actual *board.Board, msgAndArgs ...interface{}) {
+	for row := 0; row < board.ChessboardSize; row++ {
+		for col := 0; col < board.ChessboardSize; col++ {
+			fieldCords := board.Cords{Col: col, Row: row}
+			assert.Equal(t, expected.GetField(fieldCords), actual.GetField(fieldCords), msgAndArgs...)
+		}
+	}
+	assert.Equal(t, expected.SideToMove(), actual.SideToMove(), msgAndArgs...)
+	assert.Equal(t, expected.HalfmoveClock(), actual.HalfmoveClock(), msgAndArgs...)
+	assert.Equal(t, expected.FullmoveNumber(), actual.FullmoveNumber(), msgAndArgs...)
+	assert.Equal(t, expected.GetLastMove(), actual.GetLastMove(), msgAndArgs...)
+	assert.Equal(t, expected.CastlingRights(), actual.CastlingRights(), msgAndArgs...)
+	assert.Equal(t, expected.EnPassantCords(), actual.EnPassantCords(), msgAndArgs...)
+	for _, side := range []board.FigureSide{board.White, board.Black} {
+		assert.Equal(t, expected.GetKingCords(side), actual.GetKingCords(side), msgAndArgs...)
+	}
+}
+
+func TestMakeMove_MatchesMove(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	for game := 0; game < 10; game++ {
+		chessBoard := board.InitDefaultBoard()
+		initial := chessBoard.Copy()
+		copied := chessBoard.Copy()
+		for ply := 0; ply < 80; ply++ {
+			moves := generator.LegalMoves(*chessBoard)
+			if len(moves) == 0 {
+				break
+			}
+			move := moves[random.Intn(len(moves))]
+			copied = copied.Move(move)
+			chessBoard.MakeMove(move)
+			assertSameBoard(t, &copied, chessBoard, "game %d ply %d", game, ply)
+		}
+
+		for chessBoard.MadeMoves() > 0 {
+			assert.NotNil(t, chessBoard.UnmakeMove())
+		}
+		assertSameBoard(t, &initial, chessBoard, "game %d", game)
+		assert.Nil(t, chessBoard.UnmakeMove())
+	}
+}
+
+func TestUnmakeMove_RestoresSpecialMoves(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whiteKing := board.Field{Figure: board.Figure{FigureType: board.King, FigureSide: board.White}, Cords: cords("e1"), Filled: true}
+	chessBoard.SetField(board.Field{Cords: cords("a1")})
+	chessBoard.SetField(whiteKing)
+	chessBoard.SetField(board.Field{Figure: board.Figure{FigureType: board.Rook, FigureSide: board.White}, Cords: cords("h1"), Filled: true})
+	chessBoard.SetField(board.Field{Figure: board.Figure{FigureType: board.Pawn, FigureSide: board.White}, Cords: cords("b2"), Filled: true})
+	setFigure(&chessBoard, board.Pawn, board.White, 4, 4)
+	setFigure(&chessBoard, board.Pawn, board.Black, 3, 6)
+	setFigure(&chessBoard, board.Pawn, board.Black, 0, 1)
+	initial := chessBoard.Copy()
+	move := func(departure string, destination string, promoteToType board.FigureType) {
+		chessBoard.MakeMove(board.MakeMove(chessBoard.GetField(cords(departure)), chessBoard.GetField(cords(destination)), promoteToType))
+	}
+
+	move("e1", "g1", board.EmptyType)
+	move("d7", "d5", board.EmptyType)
+	move("e5", "d6", board.EmptyType)
+	move("a2", "b1", board.Knight)
+
+	assert.Equal(t, board.Rook, chessBoard.GetField(cords("f1")).Figure.FigureType)
+	assert.False(t, chessBoard.GetField(cords("d5")).Filled)
+	assert.Equal(t, board.Knight, chessBoard.GetField(cords("b1")).Figure.FigureType)
+	assert.Equal(t, cords("g1"), *chessBoard.GetKingCords(board.White))
+
+	for chessBoard.MadeMoves() > 0 {
+		chessBoard.UnmakeMove()
+	}
+	assertSameBoard(t, &initial, &chessBoard)
+	assert.True(t, chessBoard.CastlingRights().WhiteShort)
+}
+
+func TestMakeMove_DoesNotAllocate(t *testing.T) {
+	chessBoard := middlegameBoard(t)
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	moves := generator.LegalMoves(*chessBoard)
+	chessBoard.MakeMove(moves[0])
+	chessBoard.UnmakeMove()
+
+	allocations := testing.AllocsPerRun(100, func() {
+		for _, move := range moves {
+			chessBoard.MakeMove(move)
+			chessBoard.UnmakeMove()
+		}
+	})
+
+	assert.Zero(t, allocations)
+}
+
+func BenchmarkMove_Copy(b *testing.B) {
+	chessBoard := middlegameBoard(b)
+	moves := board.MakeMoveGenerator(board.InitValidators()).LegalMoves(*chessBoard)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, move := range moves {
+			chessBoard.Move(move)
+		}
+	}
+}
+
+func BenchmarkMove_MakeUnmake(b *testing.B) {
+	chessBoard := middlegameBoard(b)
+	moves := board.MakeMoveGenerator(board.InitValidators()).LegalMoves(*chessBoard)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, move := range moves {
+			chessBoard.MakeMove(move)
+			chessBoard.UnmakeMove()
+		}
+	}
+}
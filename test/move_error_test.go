@@ -0,0 +1,153 @@
+package test
+
+import (
+	"chess/board"
+	"chess/session"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func assertValidationError(t *testing.T, err error, validator string, code board.ValidationErrorCode) {
+	var validationError *board.ValidationError
+	if assert.True(t, errors.As(err, &validationError)) {
+		assert.Equal(t, validator, validationError.Validator)
+		assert.Equal(t, code, validationError.Code)
+	}
+}
+
+func TestSessionMoveError_WrongSide(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 0, Row: 6},
+		DestinationCords: board.Cords{Col: 0, Row: 5},
+	})
+
+	assertValidationError(t, err, "SideToMoveValidator", board.WrongSideCode)
+	assert.Len(t, chessSession.BoardHistory, 0)
+}
+
+func TestSessionMoveError_EmptyDeparture(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 0, Row: 3},
+		DestinationCords: board.Cords{Col: 0, Row: 4},
+	})
+
+	assertValidationError(t, err, "MoveGenerator", board.EmptyDepartureCode)
+}
+
+func TestSessionMoveError_PathBlocked(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 0, Row: 0},
+		DestinationCords: board.Cords{Col: 0, Row: 4},
+	})
+
+	assertValidationError(t, err, "LinePathValidator", board.PathBlockedCode)
+}
+
+func TestSessionMoveError_AllyChessman(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 0, Row: 0},
+		DestinationCords: board.Cords{Col: 0, Row: 1},
+	})
+
+	assertValidationError(t, err, "NotAllyChessmanValidator", board.AllyChessmanCode)
+}
+
+func TestSessionMoveError_IllegalFigureMove(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   board.Cords{Col: 1, Row: 0},
+		DestinationCords: board.Cords{Col: 1, Row: 2},
+	})
+
+	assertValidationError(t, err, "KnightMoveValidator", board.IllegalFigureMoveCode)
+}
+
+func TestSessionMoveError_KingAttacked(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: true}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: board.Cords{Col: 0, Row: 0}, Filled: true})
+	whiteKnight := board.Figure{FigureType: board.Knight, FigureSide: board.White, Moved: true}
+	whiteKnightCords := board.Cords{Col: 0, Row: 1}
+	chessBoard.SetField(board.Field{Figure: whiteKnight, Cords: whiteKnightCords, Filled: true})
+	blackRook := board.Figure{FigureType: board.Rook, FigureSide: board.Black, Moved: true}
+	chessBoard.SetField(board.Field{Figure: blackRook, Cords: board.Cords{Col: 0, Row: 7}, Filled: true})
+	chessSession := session.MakeUncheckedSession(&chessBoard)
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   whiteKnightCords,
+		DestinationCords: board.Cords{Col: 2, Row: 2},
+	})
+
+	assertValidationError(t, err, "KingIsNotAttackedAfterMoveValidator", board.KingAttackedCode)
+}
+
+func TestSessionMoveError_PromotionTypeMissing(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: true}
+	whitePawnCords := board.Cords{Col: 0, Row: 6}
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: whitePawnCords, Filled: true})
+	chessSession := session.MakeUncheckedSession(&chessBoard)
+
+	missingErr := chessSession.Move(session.MoveRequest{
+		DepartureCords:   whitePawnCords,
+		DestinationCords: board.Cords{Col: 0, Row: 7},
+	})
+	invalidErr := chessSession.Move(session.MoveRequest{
+		DepartureCords:   whitePawnCords,
+		DestinationCords: board.Cords{Col: 0, Row: 7},
+		PromoteToType:    board.King,
+	})
+
+	assertValidationError(t, missingErr, "PromotionMoveValidator", board.PromotionTypeMissingCode)
+	assertValidationError(t, invalidErr, "PromotionMoveValidator", board.PromotionTypeInvalidCode)
+}
+
+func TestSessionMoveError_CastlingNotAllowed(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: true}
+	whiteKingCords := board.Cords{Col: 4, Row: 0}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: whiteKingCords, Filled: true})
+	whiteRook := board.Figure{FigureType: board.Rook, FigureSide: board.White, Moved: false}
+	chessBoard.SetField(board.Field{Figure: whiteRook, Cords: board.Cords{Col: 7, Row: 0}, Filled: true})
+	chessSession := session.MakeUncheckedSession(&chessBoard)
+
+	err := chessSession.Move(session.MoveRequest{
+		DepartureCords:   whiteKingCords,
+		DestinationCords: board.Cords{Col: 6, Row: 0},
+	})
+
+	assertValidationError(t, err, "CastlingMoveValidator", board.CastlingNotAllowedCode)
+	assert.EqualError(t, err, "CastlingMoveValidator: castling is not allowed")
+}
+
+func TestSessionMoveError_OutOfBorders(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	for _, request := range []session.MoveRequest{
+		{DepartureCords: board.Cords{Col: 0, Row: 0}, DestinationCords: board.Cords{Col: 8, Row: 0}},
+		{DepartureCords: board.Cords{Col: 4, Row: 1}, DestinationCords: board.Cords{Col: 4, Row: -1}},
+		{DepartureCords: board.Cords{Col: -1, Row: 1}, DestinationCords: board.Cords{Col: 0, Row: 2}},
+	} {
+		assert.NotPanics(t, func() {
+			assertValidationError(t, chessSession.Move(request), "BordersBreachValidator", board.OutOfBordersCode)
+		})
+	}
+	assert.Len(t, chessSession.BoardHistory, 0)
+}
+
+func TestValidationErrorCode_ZeroValueIsUnknown(t *testing.T) {
+	var code board.ValidationErrorCode
+
+	assert.Equal(t, board.UnknownValidationCode, code)
+	assert.NotEqual(t, board.OutOfBordersCode, code)
+}
@@ -23,7 +23,7 @@ func TestHasAvailableMoves_PawnNoAvailableMoves_CoversKing(t *testing.T) {
 	blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 	chessBoard.SetField(blackRookField)
 
-	validators := board.InitValidators(&chessBoard)
+	validators := board.InitValidators()
 	generator := board.MakeMoveGenerator(validators)
 
 	hasAvailableMoves := generator.HasAvailableMoves(chessBoard, whitePawnField)
@@ -37,7 +37,7 @@ func TestHasAvailableMoves_PawnHasAvailableMoves(t *testing.T) {
 	whitePawnField := board.Field{Figure: whitePawn, Cords: whitePawnCords, Filled: true}
 	chessBoard.SetField(whitePawnField)
 
-	validators := board.InitValidators(&chessBoard)
+	validators := board.InitValidators()
 	generator := board.MakeMoveGenerator(validators)
 
 	hasAvailableMoves := generator.HasAvailableMoves(chessBoard, whitePawnField)
@@ -56,9 +56,131 @@ func TestHasAvailableMoves_PawnNoAvailableMoves(t *testing.T) {
 	blackPawnField := board.Field{Figure: blackPawn, Cords: blackPawnCords, Filled: true}
 	chessBoard.SetField(blackPawnField)
 
-	validators := board.InitValidators(&chessBoard)
+	validators := board.InitValidators()
 	generator := board.MakeMoveGenerator(validators)
 
 	hasAvailableMoves := generator.HasAvailableMoves(chessBoard, whitePawnField)
 	assert.False(t, hasAvailableMoves)
 }
+
+func TestLegalMoves_DefaultBoard(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	assert.Len(t, generator.LegalMoves(*chessBoard), 20)
+}
+
+func TestLegalMovesFrom_KnightOnDefaultBoard(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	moves := generator.LegalMovesFrom(*chessBoard, board.Cords{Col: 1, Row: 0})
+
+	assert.ElementsMatch(t, []board.Cords{{Col: 0, Row: 2}, {Col: 2, Row: 2}}, destinations(moves))
+}
+
+func TestLegalMovesFrom_OpposedSide(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	assert.Empty(t, generator.LegalMovesFrom(*chessBoard, board.Cords{Col: 1, Row: 7}))
+	assert.Empty(t, generator.LegalMovesFrom(*chessBoard, board.Cords{Col: 4, Row: 4}))
+}
+
+func TestLegalMovesFrom_AllPromotions(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: true}
+	whitePawnCords := board.Cords{Col: 0, Row: 6}
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: whitePawnCords, Filled: true})
+	blackRook := board.Figure{FigureType: board.Rook, FigureSide: board.Black, Moved: true}
+	chessBoard.SetField(board.Field{Figure: blackRook, Cords: board.Cords{Col: 1, Row: 7}, Filled: true})
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	moves := generator.LegalMovesFrom(chessBoard, whitePawnCords)
+
+	assert.Len(t, moves, 8)
+	promoteToTypes := make(map[board.Cords][]board.FigureType)
+	for _, move := range moves {
+		promotionMove, isPromotionMove := move.(board.PromotionMove)
+		assert.True(t, isPromotionMove)
+		cords := move.Destination().Cords
+		promoteToTypes[cords] = append(promoteToTypes[cords], promotionMove.PromoteToType())
+	}
+	allTypes := []board.FigureType{board.Queen, board.Rook, board.Bishop, board.Knight}
+	assert.ElementsMatch(t, allTypes, promoteToTypes[board.Cords{Col: 0, Row: 7}])
+	assert.ElementsMatch(t, allTypes, promoteToTypes[board.Cords{Col: 1, Row: 7}])
+}
+
+func TestLegalMovesFrom_BothCastles(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: false}
+	whiteKingCords := board.Cords{Col: 4, Row: 0}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: whiteKingCords, Filled: true})
+	whiteRook := board.Figure{FigureType: board.Rook, FigureSide: board.White, Moved: false}
+	chessBoard.SetField(board.Field{Figure: whiteRook, Cords: board.Cords{Col: 0, Row: 0}, Filled: true})
+	chessBoard.SetField(board.Field{Figure: whiteRook, Cords: board.Cords{Col: 7, Row: 0}, Filled: true})
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	moves := generator.LegalMovesFrom(chessBoard, whiteKingCords)
+
+	castles := 0
+	for _, move := range moves {
+		if _, isCastleMove := move.(board.CastleMove); isCastleMove {
+			castles++
+		}
+	}
+	assert.Len(t, moves, 7)
+	assert.Equal(t, 2, castles)
+}
+
+func TestLegalMovesFrom_KingAvoidsKnightAttack(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: true}
+	whiteKingCords := board.Cords{Col: 0, Row: 0}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: whiteKingCords, Filled: true})
+	blackKnight := board.Figure{FigureType: board.Knight, FigureSide: board.Black, Moved: true}
+	chessBoard.SetField(board.Field{Figure: blackKnight, Cords: board.Cords{Col: 2, Row: 2}, Filled: true})
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	moves := generator.LegalMovesFrom(chessBoard, whiteKingCords)
+
+	assert.ElementsMatch(t, []board.Cords{{Col: 1, Row: 1}}, destinations(moves))
+}
+
+func TestLegalMovesFrom_EnPassant(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: true}
+	whitePawnCords := board.Cords{Col: 0, Row: 4}
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: whitePawnCords, Filled: true})
+	blackPawn := board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: false}
+	blackPawnField := board.Field{Figure: blackPawn, Cords: board.Cords{Col: 1, Row: 6}, Filled: true}
+	chessBoard.SetField(blackPawnField)
+	chessBoard.SetSideToMove(board.Black)
+	chessBoard = chessBoard.Move(board.MakeMove(
+		blackPawnField,
+		chessBoard.GetField(board.Cords{Col: 1, Row: 4}),
+		board.EmptyType,
+	))
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	moves := generator.LegalMovesFrom(chessBoard, whitePawnCords)
+	assert.ElementsMatch(t, []board.Cords{{Col: 0, Row: 5}, {Col: 1, Row: 5}}, destinations(moves))
+
+	var enPassantMove board.Move
+	for _, move := range moves {
+		if move.Destination().Cords.Col == 1 {
+			enPassantMove = move
+		}
+	}
+	afterEnPassant := chessBoard.Move(enPassantMove)
+	assert.True(t, afterEnPassant.GetField(board.Cords{Col: 1, Row: 5}).Filled)
+	assert.False(t, afterEnPassant.GetField(board.Cords{Col: 1, Row: 4}).Filled)
+}
+
+func destinations(moves []board.Move) []board.Cords {
+	cords := make([]board.Cords, 0, len(moves))
+	for _, move := range moves {
+		cords = append(cords, move.Destination().Cords)
+	}
+	return cords
+}
@@ -22,9 +22,9 @@ func TestPawnMoveForwardPassEnemyPawn(t *testing.T) {
 	destinationField := chessBoard.GetField(board.Cords{Col: 0, Row: 7})
 
 	move := board.MakeMove(whitePawnField, destinationField, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	assert.False(t, validator.Validate(move))
+	assert.Error(t, validator.Validate(&chessBoard, move))
 }
 
 func TestPawnMoveForwardToEnemyPawn(t *testing.T) {
@@ -40,9 +40,9 @@ func TestPawnMoveForwardToEnemyPawn(t *testing.T) {
 	chessBoard.SetField(blackPawnField)
 
 	move := board.MakeMove(whitePawnField, blackPawnField, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	assert.False(t, validator.Validate(move))
+	assert.Error(t, validator.Validate(&chessBoard, move))
 }
 
 func TestPawnEnPassant_Fail_KillDestinationTooFarRow(t *testing.T) {
@@ -54,9 +54,9 @@ func TestPawnEnPassant_Fail_KillDestinationTooFarRow(t *testing.T) {
 	chessBoard.SetField(whitePawnField)
 	killDestination := chessBoard.GetField(board.Cords{Col: 1, Row: 6})
 	move := board.MakeMove(whitePawnField, killDestination, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	assert.False(t, validator.Validate(move))
+	assert.Error(t, validator.Validate(&chessBoard, move))
 }
 
 func TestPawnEnPassant_Fail_KillDestinationTooFarCol(t *testing.T) {
@@ -68,9 +68,9 @@ func TestPawnEnPassant_Fail_KillDestinationTooFarCol(t *testing.T) {
 	chessBoard.SetField(whitePawnField)
 	killDestination := chessBoard.GetField(board.Cords{Col: 2, Row: 5})
 	move := board.MakeMove(whitePawnField, killDestination, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	assert.False(t, validator.Validate(move))
+	assert.Error(t, validator.Validate(&chessBoard, move))
 }
 
 func TestPawnEnPassant_Fail_ClosePawnMovedShort(t *testing.T) {
@@ -88,9 +88,9 @@ func TestPawnEnPassant_Fail_ClosePawnMovedShort(t *testing.T) {
 	chessBoard = chessBoard.Move(board.MakeMove(blackPawnField, blackPawnShortMoveDestinationField, board.EmptyType))
 	whitePawnEnPassantMoveDestinationField := chessBoard.GetField(board.Cords{Col: 1, Row: 5})
 	move := board.MakeMove(whitePawnField, whitePawnEnPassantMoveDestinationField, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	assert.False(t, validator.Validate(move))
+	assert.Error(t, validator.Validate(&chessBoard, move))
 }
 
 func TestPawnEnPassant_Success(t *testing.T) {
@@ -108,9 +108,9 @@ func TestPawnEnPassant_Success(t *testing.T) {
 	chessBoard = chessBoard.Move(board.MakeMove(blackPawnField, blackPawnLongMoveDestinationField, board.EmptyType))
 	whitePawnEnPassantMoveDestinationField := chessBoard.GetField(board.Cords{Col: 1, Row: 5})
 	move := board.MakeMove(whitePawnField, whitePawnEnPassantMoveDestinationField, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	assert.True(t, validator.Validate(move))
+	assert.NoError(t, validator.Validate(&chessBoard, move))
 }
 
 func TestPawnKillFigureValidation_Success(t *testing.T) {
@@ -136,9 +136,9 @@ func testPawnKill(col int, destCol int) bool {
 	blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 	chessBoard.SetField(blackRookField)
 	move := board.MakeMove(whitePawnField, blackRookField, board.EmptyType)
-	validator := board.PawnMoveValidator{ActualBoard: &chessBoard}
+	validator := board.PawnMoveValidator{}
 
-	return validator.Validate(move)
+	return validator.Validate(&chessBoard, move) == nil
 }
 
 func TestPromotionMoveAllowedTypes(t *testing.T) {
@@ -151,14 +151,14 @@ func TestPromotionMoveAllowedTypes(t *testing.T) {
 		whitePawnField := board.Field{Figure: whitePawn, Cords: whitePawnCords, Filled: true}
 		chessBoard.SetField(whitePawnField)
 		destinationCords := board.Cords{Col: 0, Row: 7}
-		gameSession := session.MakeSession(&chessBoard)
+		gameSession := session.MakeUncheckedSession(&chessBoard)
 		moveRequest := session.MoveRequest{
 			DepartureCords:   whitePawnCords,
 			DestinationCords: destinationCords,
 			PromoteToType:    figureType,
 		}
 
-		isMoved := gameSession.Move(moveRequest)
+		isMoved := gameSession.TryMove(moveRequest)
 		actualBoard := gameSession.ActualBoard
 
 		assert.True(t, isMoved)
@@ -177,14 +177,14 @@ func TestPromotionMoveNotAllowedTypes(t *testing.T) {
 		chessBoard.SetField(whitePawnField)
 		destinationCords := board.Cords{Col: 0, Row: 7}
 
-		gameSession := session.MakeSession(&chessBoard)
+		gameSession := session.MakeUncheckedSession(&chessBoard)
 		moveRequest := session.MoveRequest{
 			DepartureCords:   whitePawnCords,
 			DestinationCords: destinationCords,
 			PromoteToType:    figureType,
 		}
 
-		isMoved := gameSession.Move(moveRequest)
+		isMoved := gameSession.TryMove(moveRequest)
 
 		assert.False(t, isMoved)
 	}
@@ -205,14 +205,14 @@ func TestPromotionKillMoveAllowedTypes(t *testing.T) {
 		blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 		chessBoard.SetField(blackRookField)
 
-		gameSession := session.MakeSession(&chessBoard)
+		gameSession := session.MakeUncheckedSession(&chessBoard)
 		moveRequest := session.MoveRequest{
 			DepartureCords:   whitePawnCords,
 			DestinationCords: blackRookCords,
 			PromoteToType:    figureType,
 		}
 
-		isMoved := gameSession.Move(moveRequest)
+		isMoved := gameSession.TryMove(moveRequest)
 		actualBoard := gameSession.ActualBoard
 
 		assert.True(t, isMoved)
@@ -235,14 +235,14 @@ func TestPromotionKillMoveNotAllowedTypes(t *testing.T) {
 		blackRookField := board.Field{Figure: blackRook, Cords: blackRookCords, Filled: true}
 		chessBoard.SetField(blackRookField)
 
-		gameSession := session.MakeSession(&chessBoard)
+		gameSession := session.MakeUncheckedSession(&chessBoard)
 		moveRequest := session.MoveRequest{
 			DepartureCords:   whitePawnCords,
 			DestinationCords: blackRookCords,
 			PromoteToType:    figureType,
 		}
 
-		isMoved := gameSession.Move(moveRequest)
+		isMoved := gameSession.TryMove(moveRequest)
 		assert.False(t, isMoved)
 	}
 }
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"chess/bitboard"
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var perftPositions = []struct {
+	name  string
+	fen   string
+	nodes []int
+}{
+	{"initial", board.DefaultFEN, []int{20, 400, 8902}},
+	{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039}},
+	{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812}},
+	{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{6, 264, 9467}},
+	{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{44, 1486}},
+	{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []int{46, 2079}},
+}
+
+func TestPerft_ReferencePositions(t *testing.T) {
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	for _, reference := range perftPositions {
+		chessBoard, err := board.ParseFEN(reference.fen)
+		assert.NoError(t, err, reference.name)
+		for depth, nodes := range reference.nodes {
+			assert.Equal(t, nodes, generator.Perft(chessBoard, depth+1), "%s depth %d", reference.name, depth+1)
+		}
+		assert.Equal(t, reference.fen, chessBoard.FEN(), reference.name)
+	}
+}
+
+func TestPerft_BitboardReferencePositions(t *testing.T) {
+	deeper := map[string]int{"kiwipete": 97862, "position 3": 43238, "position 5": 62379, "position 6": 89890}
+	for _, reference := range perftPositions {
+		chessBoard, err := board.ParseFEN(reference.fen)
+		assert.NoError(t, err, reference.name)
+		position := bitboard.FromBoard(chessBoard)
+		for depth, nodes := range reference.nodes {
+			assert.Equal(t, nodes, position.Perft(depth+1), "%s depth %d", reference.name, depth+1)
+		}
+		if nodes, ok := deeper[reference.name]; ok {
+			depth := 3
+			if reference.name == "position 3" {
+				depth = 4
+			}
+			assert.Equal(t, nodes, position.Perft(depth), reference.name)
+		}
+	}
+}
+
+func TestDivide(t *testing.T) {
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	chessBoard := board.InitDefaultBoard()
+
+	results := generator.Divide(chessBoard, 3)
+
+	total := 0
+	nodesByMove := make(map[string]int, len(results))
+	for _, result := range results {
+		total += result.Nodes
+		nodesByMove[result.Move.String()] = result.Nodes
+	}
+	assert.Len(t, results, 20)
+	assert.Equal(t, 8902, total)
+	assert.Equal(t, 600, nodesByMove["e2e4"])
+	assert.Equal(t, 380, nodesByMove["a2a3"])
+	assert.Equal(t, board.DefaultFEN, chessBoard.FEN())
+}
+
+func TestParseFEN(t *testing.T) {
+	chessBoard, err := board.ParseFEN("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, board.White, chessBoard.SideToMove())
+	assert.Equal(t, cords("e6"), *chessBoard.EnPassantCords())
+	assert.Equal(t, 2, chessBoard.FullmoveNumber())
+	assert.False(t, chessBoard.GetField(cords("d2")).Figure.Moved)
+	assert.True(t, chessBoard.GetField(cords("e4")).Figure.Moved)
+	assert.False(t, chessBoard.GetField(cords("e1")).Figure.Moved)
+	assert.True(t, chessBoard.GetField(cords("b1")).Figure.Moved)
+	assert.Equal(t, cords("e8"), *chessBoard.GetKingCords(board.Black))
+}
+
+func TestParseFEN_DefaultBoard(t *testing.T) {
+	chessBoard, err := board.ParseFEN(board.DefaultFEN)
+
+	assert.NoError(t, err)
+	assert.True(t, chessBoard.SamePosition(board.InitDefaultBoard()))
+	assert.Equal(t, board.InitDefaultBoard().Hash(), chessBoard.Hash())
+	assert.Equal(t, board.DefaultFEN, board.InitDefaultBoard().FEN())
+}
+
+func TestParseFEN_Invalid(t *testing.T) {
+	for _, fen := range []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/ppppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNX w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBN1 w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - x 1",
+	} {
+		_, err := board.ParseFEN(fen)
+		assert.ErrorIs(t, err, board.ErrInvalidFEN, fen)
+	}
+}
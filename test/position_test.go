@@ -0,0 +1,180 @@
+package test
+
+import (
+	"chess/board"
+	"chess/session"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func problemCodes(problems []board.PositionProblem) []board.PositionProblemCode {
+	codes := make([]board.PositionProblemCode, 0, len(problems))
+	for _, problem := range problems {
+		codes = append(codes, problem.Code)
+	}
+	return codes
+}
+
+func makeKingsBoard() board.Board {
+	chessBoard := board.MakeBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: true}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: board.Cords{Col: 0, Row: 0}, Filled: true})
+	blackKing := board.Figure{FigureType: board.King, FigureSide: board.Black, Moved: true}
+	chessBoard.SetField(board.Field{Figure: blackKing, Cords: board.Cords{Col: 7, Row: 7}, Filled: true})
+	return chessBoard
+}
+
+func TestValidatePosition_DefaultBoard(t *testing.T) {
+	assert.Empty(t, board.ValidatePosition(board.InitDefaultBoard()))
+}
+
+func TestValidatePosition_MissingKings(t *testing.T) {
+	chessBoard := board.MakeBoard()
+
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(t, []board.PositionProblemCode{board.MissingKingCode, board.MissingKingCode}, problemCodes(problems))
+	assert.Equal(t, board.White, problems[0].Side)
+	assert.Equal(t, board.Black, problems[1].Side)
+}
+
+func TestValidatePosition_ExtraKings(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: true}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: board.Cords{Col: 3, Row: 3}, Filled: true})
+
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(t, []board.PositionProblemCode{board.ExtraKingCode}, problemCodes(problems))
+	assert.Equal(t, board.Cords{Col: 3, Row: 3}, *problems[0].Cords)
+}
+
+func TestValidatePosition_PawnOnBackRank(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: true}
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: board.Cords{Col: 3, Row: 0}, Filled: true})
+	blackPawn := board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: true}
+	chessBoard.SetField(board.Field{Figure: blackPawn, Cords: board.Cords{Col: 3, Row: 7}, Filled: true})
+
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(t, []board.PositionProblemCode{board.PawnOnBackRankCode, board.PawnOnBackRankCode}, problemCodes(problems))
+}
+
+func TestValidatePosition_UnmovedPawnOffStartRow(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: false}
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: board.Cords{Col: 3, Row: 3}, Filled: true})
+	blackPawn := board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: false}
+	chessBoard.SetField(board.Field{Figure: blackPawn, Cords: board.Cords{Col: 2, Row: 6}, Filled: true})
+
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(t, []board.PositionProblemCode{board.UnmovedPawnOffStartRowCode}, problemCodes(problems))
+	assert.Equal(t, board.White, problems[0].Side)
+	assert.Equal(t, board.Cords{Col: 3, Row: 3}, *problems[0].Cords)
+
+	whitePawn.Moved = true
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: board.Cords{Col: 3, Row: 3}, Filled: true})
+	assert.Empty(t, board.ValidatePosition(&chessBoard))
+}
+
+func TestValidatePosition_SideNotToMoveInCheck(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whiteRook := board.Figure{FigureType: board.Rook, FigureSide: board.White, Moved: true}
+	chessBoard.SetField(board.Field{Figure: whiteRook, Cords: board.Cords{Col: 7, Row: 1}, Filled: true})
+
+	problems := board.ValidatePosition(&chessBoard)
+	assert.Equal(t, []board.PositionProblemCode{board.SideNotToMoveInCheckCode}, problemCodes(problems))
+	assert.Equal(t, board.Black, problems[0].Side)
+
+	chessBoard.SetSideToMove(board.Black)
+	assert.Empty(t, board.ValidatePosition(&chessBoard))
+}
+
+func TestValidatePosition_ImpossibleCastlingRights(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whiteKing := board.Figure{FigureType: board.King, FigureSide: board.White, Moved: false}
+	chessBoard.SetField(board.Field{Figure: whiteKing, Cords: board.Cords{Col: 3, Row: 0}, Filled: true})
+	blackKing := board.Figure{FigureType: board.King, FigureSide: board.Black, Moved: false}
+	chessBoard.SetField(board.Field{Figure: blackKing, Cords: board.Cords{Col: 4, Row: 7}, Filled: true})
+
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(t, []board.PositionProblemCode{board.ImpossibleCastlingRightsCode}, problemCodes(problems))
+	assert.Equal(t, board.White, problems[0].Side)
+}
+
+func TestValidatePosition_ImpossibleEnPassant(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: false}
+	whitePawnField := board.Field{Figure: whitePawn, Cords: board.Cords{Col: 3, Row: 1}, Filled: true}
+	chessBoard.SetField(whitePawnField)
+	chessBoard = chessBoard.Move(board.MakeMove(
+		whitePawnField,
+		chessBoard.GetField(board.Cords{Col: 3, Row: 3}),
+		board.EmptyType,
+	))
+	assert.Equal(t, board.Cords{Col: 3, Row: 2}, *chessBoard.EnPassantCords())
+	assert.Empty(t, board.ValidatePosition(&chessBoard))
+
+	chessBoard.SetField(board.Field{Cords: board.Cords{Col: 3, Row: 3}})
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(t, []board.PositionProblemCode{board.ImpossibleEnPassantCode}, problemCodes(problems))
+}
+
+func TestValidatePosition_FigureCountLimits(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	whitePawn := board.Figure{FigureType: board.Pawn, FigureSide: board.White, Moved: true}
+	whiteQueen := board.Figure{FigureType: board.Queen, FigureSide: board.White, Moved: true}
+	for col := 0; col < board.ChessboardSize; col++ {
+		chessBoard.SetField(board.Field{Figure: whitePawn, Cords: board.Cords{Col: col, Row: 2}, Filled: true})
+		chessBoard.SetField(board.Field{Figure: whiteQueen, Cords: board.Cords{Col: col, Row: 4}, Filled: true})
+	}
+	chessBoard.SetField(board.Field{Figure: whitePawn, Cords: board.Cords{Col: 1, Row: 1}, Filled: true})
+	chessBoard.SetSideToMove(board.Black)
+
+	problems := board.ValidatePosition(&chessBoard)
+
+	assert.Equal(
+		t,
+		[]board.PositionProblemCode{board.TooManyFiguresCode, board.TooManyPawnsCode, board.TooManyPromotedFiguresCode},
+		problemCodes(problems),
+	)
+}
+
+func TestCastlingRights(t *testing.T) {
+	defaultBoard := board.InitDefaultBoard()
+	assert.Equal(t, board.CastlingRights{WhiteShort: true, WhiteLong: true, BlackShort: true, BlackLong: true},
+		defaultBoard.CastlingRights())
+
+	movedRook := board.Figure{FigureType: board.Rook, FigureSide: board.White, Moved: true}
+	defaultBoard.SetField(board.Field{Figure: movedRook, Cords: board.Cords{Col: 7, Row: 0}, Filled: true})
+	defaultBoard.SetField(board.Field{Cords: board.Cords{Col: 0, Row: 7}})
+	assert.Equal(t, board.CastlingRights{WhiteLong: true, BlackShort: true}, defaultBoard.CastlingRights())
+}
+
+func TestMakeSession_IllegalPosition(t *testing.T) {
+	chessBoard := board.MakeBoard()
+
+	_, err := session.MakeSession(&chessBoard)
+
+	var positionError *board.PositionError
+	assert.True(t, errors.As(err, &positionError))
+	assert.Len(t, positionError.Problems, 2)
+	assert.EqualError(t, err, "illegal position: white: king is missing; black: king is missing")
+
+	uncheckedSession := session.MakeUncheckedSession(&chessBoard)
+	assert.Equal(t, &chessBoard, uncheckedSession.ActualBoard)
+}
+
+func TestMakeSession_LegalPosition(t *testing.T) {
+	chessBoard := makeKingsBoard()
+
+	chessSession, err := session.MakeSession(&chessBoard)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &chessBoard, chessSession.ActualBoard)
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"chess/board"
+	"chess/search"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQuiescence_DoesNotTakeDefendedPawn(t *testing.T) {
+	result, err := searchFEN(t, "4k3/8/3p4/4p3/8/8/8/4QK2 w - - 0 1", search.Limits{Depth: 1})
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, moveRequest("e1e5"), result.Move)
+	assert.Equal(t, 700, result.Score)
+}
+
+func TestQuiescence_ResolvesExchange(t *testing.T) {
+	result, err := searchFEN(t, "3rk3/8/8/3p4/8/8/3R4/3RK3 w - - 0 1", search.Limits{Depth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("d2d5"), result.Move)
+	assert.Equal(t, 500, result.Score)
+
+	result, err = searchFEN(t, "3rk3/8/8/3p4/8/8/3R4/4K3 w - - 0 1", search.Limits{Depth: 1})
+	assert.NoError(t, err)
+	assert.NotEqual(t, moveRequest("d2d5"), result.Move)
+	assert.Equal(t, -100, result.Score)
+}
+
+func TestQuiescence_SearchesEvasions(t *testing.T) {
+	result, err := searchFEN(t, "q3k3/8/8/1N6/8/8/8/4K3 w - - 0 1", search.Limits{Depth: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("b5c7"), result.Move)
+	assert.Equal(t, 300, result.Score)
+}
+
+func TestQuiescence_FindsMateAtLeaf(t *testing.T) {
+	result, err := searchFEN(t, "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", search.Limits{Depth: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("a1a8"), result.Move)
+	assert.Equal(t, 1, search.MateIn(result.Score))
+}
+
+func TestQuiescence_ChecksAtFirstPly(t *testing.T) {
+	fen := "k7/8/5Q2/8/3p4/4n3/5PP1/r6K w - - 0 1"
+	position, err := board.ParseFEN(fen)
+	assert.NoError(t, err)
+
+	searcher := search.MakeSearcher(nil)
+	withoutChecks, err := searcher.Search(context.Background(), position, search.Limits{Depth: 1})
+	assert.NoError(t, err)
+	searcher.UseQuiescenceChecks(true)
+	withChecks, err := searcher.Search(context.Background(), position, search.Limits{Depth: 1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, moveRequest("h1h2"), withoutChecks.Move)
+	assert.Greater(t, withoutChecks.Score, 0)
+	assert.Less(t, withChecks.Score, 0)
+	assert.Greater(t, withChecks.Nodes, withoutChecks.Nodes)
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// alignedPairs returns every pair of distinct cords sharing a diagonal (diagonal is true) or a line (diagonal is false)
+func alignedPairs(diagonal bool) [][2]board.Cords {
+	var pairs [][2]board.Cords
+	for from := 0; from < board.ChessboardSize*board.ChessboardSize; from++ {
+		for to := 0; to < board.ChessboardSize*board.ChessboardSize; to++ {
+			fromCords := board.Cords{Col: from % board.ChessboardSize, Row: from / board.ChessboardSize}
+			toCords := board.Cords{Col: to % board.ChessboardSize, Row: to / board.ChessboardSize}
+			direction, aligned := board.DirectionBetween(fromCords, toCords)
+			if aligned && direction.IsDiagonal() == diagonal {
+				pairs = append(pairs, [2]board.Cords{fromCords, toCords})
+			}
+		}
+	}
+	return pairs
+}
+
+func distance(from board.Cords, to board.Cords) int {
+	return max(abs(to.Col-from.Col), abs(to.Row-from.Row))
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func TestDirectionBetween_NotAligned(t *testing.T) {
+	_, aligned := board.DirectionBetween(board.Cords{Col: 0, Row: 0}, board.Cords{Col: 1, Row: 2})
+	assert.False(t, aligned)
+	_, aligned = board.DirectionBetween(board.Cords{Col: 3, Row: 3}, board.Cords{Col: 3, Row: 3})
+	assert.False(t, aligned)
+	assert.Nil(t, board.Between(board.Cords{Col: 0, Row: 0}, board.Cords{Col: 1, Row: 2}))
+}
+
+func TestAlignedPairs_Count(t *testing.T) {
+	// every field sees 14 fields along lines and 560 ordered pairs share a diagonal
+	assert.Len(t, alignedPairs(false), 64*14)
+	assert.Len(t, alignedPairs(true), 560)
+}
+
+func TestBetween_AllDiagonalsAndLines(t *testing.T) {
+	for _, diagonal := range []bool{true, false} {
+		for _, pair := range alignedPairs(diagonal) {
+			from, to := pair[0], pair[1]
+			between := board.Between(from, to)
+			assert.Len(t, between, distance(from, to)-1, "from %v to %v", from, to)
+			previous := from
+			for _, cords := range between {
+				assert.Equal(t, 1, distance(previous, cords), "from %v to %v", from, to)
+				previous = cords
+			}
+			if len(between) > 0 {
+				assert.Equal(t, 1, distance(between[len(between)-1], to))
+			}
+		}
+	}
+}
+
+func TestDiagonalPathValidator_AllDiagonals(t *testing.T) {
+	whiteBishop := board.Figure{FigureType: board.Bishop, FigureSide: board.White, Moved: true}
+	blackPawn := board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: true}
+	for _, pair := range alignedPairs(true) {
+		from, to := pair[0], pair[1]
+		chessBoard := board.MakeBoard()
+		bishopField := board.Field{Figure: whiteBishop, Cords: from, Filled: true}
+		chessBoard.SetField(bishopField)
+		move := board.MakeMove(bishopField, chessBoard.GetField(to), board.EmptyType)
+		validator := board.DiagonalPathValidator{}
+
+		assert.NoError(t, validator.Validate(&chessBoard, move), "from %v to %v", from, to)
+
+		for _, blockerCords := range board.Between(from, to) {
+			blockedBoard := chessBoard.Copy()
+			blockedBoard.SetField(board.Field{Figure: blackPawn, Cords: blockerCords, Filled: true})
+
+			err := validator.Validate(&blockedBoard, move)
+			assertValidationError(t, err, "DiagonalPathValidator", board.PathBlockedCode)
+		}
+	}
+}
+
+func TestLinePathValidator_AllLines(t *testing.T) {
+	whiteRook := board.Figure{FigureType: board.Rook, FigureSide: board.White, Moved: true}
+	blackPawn := board.Figure{FigureType: board.Pawn, FigureSide: board.Black, Moved: true}
+	for _, pair := range alignedPairs(false) {
+		from, to := pair[0], pair[1]
+		chessBoard := board.MakeBoard()
+		rookField := board.Field{Figure: whiteRook, Cords: from, Filled: true}
+		chessBoard.SetField(rookField)
+		move := board.MakeMove(rookField, chessBoard.GetField(to), board.EmptyType)
+		validator := board.LinePathValidator{}
+
+		assert.NoError(t, validator.Validate(&chessBoard, move), "from %v to %v", from, to)
+
+		for _, blockerCords := range board.Between(from, to) {
+			blockedBoard := chessBoard.Copy()
+			blockedBoard.SetField(board.Field{Figure: blackPawn, Cords: blockerCords, Filled: true})
+
+			err := validator.Validate(&blockedBoard, move)
+			assertValidationError(t, err, "LinePathValidator", board.PathBlockedCode)
+		}
+	}
+}
+
+func TestIsFieldAttackedByBishop_AllDiagonals(t *testing.T) {
+	whiteBishop := board.Figure{FigureType: board.Bishop, FigureSide: board.White, Moved: true}
+	blackKnight := board.Figure{FigureType: board.Knight, FigureSide: board.Black, Moved: true}
+	for _, pair := range alignedPairs(true) {
+		from, to := pair[0], pair[1]
+		chessBoard := board.MakeBoard()
+		chessBoard.SetField(board.Field{Figure: whiteBishop, Cords: from, Filled: true})
+
+		assert.True(t, chessBoard.IsFieldAttackedByOpposedSide(to, board.Black), "from %v to %v", from, to)
+
+		for _, blockerCords := range board.Between(from, to) {
+			blockedBoard := chessBoard.Copy()
+			blockedBoard.SetField(board.Field{Figure: blackKnight, Cords: blockerCords, Filled: true})
+
+			assert.False(t, blockedBoard.IsFieldAttackedByOpposedSide(to, board.Black), "from %v to %v", from, to)
+		}
+	}
+}
+
+func TestFirstBlocker(t *testing.T) {
+	chessBoard := board.InitDefaultBoard()
+	from := board.Cords{Col: 3, Row: 3}
+
+	blocker, found := chessBoard.FirstBlocker(from, board.Direction{ColDelta: 1, RowDelta: 1})
+	assert.True(t, found)
+	assert.Equal(t, board.Cords{Col: 6, Row: 6}, blocker.Cords)
+
+	blocker, found = chessBoard.FirstBlocker(from, board.Direction{ColDelta: -1, RowDelta: -1})
+	assert.True(t, found)
+	assert.Equal(t, board.Cords{Col: 1, Row: 1}, blocker.Cords)
+
+	_, found = chessBoard.FirstBlocker(from, board.Direction{ColDelta: 1, RowDelta: 0})
+	assert.False(t, found)
+}
+
+func TestLegalMovesFrom_BishopOffLongDiagonals(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	whiteBishop := board.Figure{FigureType: board.Bishop, FigureSide: board.White, Moved: true}
+	whiteBishopCords := board.Cords{Col: 2, Row: 0}
+	chessBoard.SetField(board.Field{Figure: whiteBishop, Cords: whiteBishopCords, Filled: true})
+	generator := board.MakeMoveGenerator(board.InitValidators())
+
+	assert.Len(t, generator.LegalMovesFrom(chessBoard, whiteBishopCords), 7)
+}
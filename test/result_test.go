@@ -0,0 +1,184 @@
+package test
+
+import (
+	"chess/board"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBoardMove_Counters(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	assert.Equal(t, 0, chessSession.ActualBoard.HalfmoveClock())
+	assert.Equal(t, 1, chessSession.ActualBoard.FullmoveNumber())
+
+	playMoves(t, &chessSession, "g1f3", "g8f6")
+	assert.Equal(t, 2, chessSession.ActualBoard.HalfmoveClock())
+	assert.Equal(t, 2, chessSession.ActualBoard.FullmoveNumber())
+
+	playMoves(t, &chessSession, "e2e4", "f6e4")
+	assert.Equal(t, 0, chessSession.ActualBoard.HalfmoveClock())
+	assert.Equal(t, 3, chessSession.ActualBoard.FullmoveNumber())
+}
+
+func TestSession_Resign(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4")
+
+	assert.NoError(t, chessSession.Resign(board.White))
+
+	assert.True(t, chessSession.IsOver())
+	assert.Equal(t, session.BlackWon, chessSession.Result())
+	assert.Equal(t, session.Resignation, chessSession.Termination())
+	assert.Equal(t, []session.Action{
+		{Type: session.ResignAction, Side: board.White, Ply: 1, Termination: session.Resignation},
+	}, chessSession.ActionHistory)
+	assert.ErrorIs(t, chessSession.Move(session.MoveRequest{
+		DepartureCords:   cords("e7"),
+		DestinationCords: cords("e5"),
+	}), session.ErrGameOver)
+	assert.ErrorIs(t, chessSession.Resign(board.Black), session.ErrGameOver)
+}
+
+func TestSession_ActionsRequireSide(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4")
+
+	for _, side := range []board.FigureSide{board.EmptySide, board.FigureSide(7)} {
+		assert.ErrorIs(t, chessSession.Resign(side), session.ErrInvalidSide)
+		assert.ErrorIs(t, chessSession.OfferDraw(side), session.ErrInvalidSide)
+		assert.ErrorIs(t, chessSession.RequestTakeback(side), session.ErrInvalidSide)
+	}
+	assert.NoError(t, chessSession.OfferDraw(board.White))
+	assert.ErrorIs(t, chessSession.AcceptDraw(board.EmptySide), session.ErrInvalidSide)
+	assert.ErrorIs(t, chessSession.DeclineDraw(board.EmptySide), session.ErrInvalidSide)
+	assert.ErrorIs(t, chessSession.WithdrawDraw(board.EmptySide), session.ErrInvalidSide)
+	assert.NoError(t, chessSession.WithdrawDraw(board.White))
+	assert.NoError(t, chessSession.RequestTakeback(board.White))
+	assert.ErrorIs(t, chessSession.AcceptTakeback(board.EmptySide), session.ErrInvalidSide)
+	assert.ErrorIs(t, chessSession.DeclineTakeback(board.EmptySide), session.ErrInvalidSide)
+
+	assert.False(t, chessSession.IsOver())
+	assert.Equal(t, []session.ActionType{
+		session.OfferDrawAction, session.WithdrawDrawAction, session.RequestTakebackAction,
+	}, actionTypes(chessSession.ActionHistory))
+}
+
+func actionTypes(actions []session.Action) []session.ActionType {
+	types := make([]session.ActionType, 0, len(actions))
+	for _, action := range actions {
+		types = append(types, action.Type)
+	}
+	return types
+}
+
+func TestSession_DrawAgreement(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	assert.NoError(t, chessSession.OfferDraw(board.White))
+	assert.ErrorIs(t, chessSession.OfferDraw(board.Black), session.ErrDrawAlreadyOffered)
+	assert.ErrorIs(t, chessSession.AcceptDraw(board.White), session.ErrNoDrawOffer)
+	offeringSide, offered := chessSession.DrawOffer()
+	assert.True(t, offered)
+	assert.Equal(t, board.White, offeringSide)
+
+	assert.NoError(t, chessSession.AcceptDraw(board.Black))
+
+	assert.Equal(t, session.Draw, chessSession.Result())
+	assert.Equal(t, session.DrawAgreement, chessSession.Termination())
+	assert.Len(t, chessSession.ActionHistory, 2)
+	assert.Equal(t, session.AcceptDrawAction, chessSession.ActionHistory[1].Type)
+}
+
+func TestSession_DeclineAndWithdrawDraw(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	assert.NoError(t, chessSession.OfferDraw(board.White))
+	assert.NoError(t, chessSession.DeclineDraw(board.Black))
+	_, offered := chessSession.DrawOffer()
+	assert.False(t, offered)
+
+	assert.NoError(t, chessSession.OfferDraw(board.Black))
+	assert.ErrorIs(t, chessSession.WithdrawDraw(board.White), session.ErrNoDrawOffer)
+	assert.NoError(t, chessSession.WithdrawDraw(board.Black))
+	assert.ErrorIs(t, chessSession.AcceptDraw(board.White), session.ErrNoDrawOffer)
+
+	assert.False(t, chessSession.IsOver())
+	assert.Len(t, chessSession.ActionHistory, 4)
+}
+
+func TestSession_DrawOfferExpiresAfterOpponentMove(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	assert.NoError(t, chessSession.OfferDraw(board.White))
+	playMoves(t, &chessSession, "e2e4")
+	_, offered := chessSession.DrawOffer()
+	assert.True(t, offered)
+
+	playMoves(t, &chessSession, "e7e5")
+	_, offered = chessSession.DrawOffer()
+	assert.False(t, offered)
+	assert.ErrorIs(t, chessSession.AcceptDraw(board.Black), session.ErrNoDrawOffer)
+}
+
+func TestSession_ClaimThreefoldRepetition(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "g1f3", "g8f6", "f3g1", "f6g8", "g1f3", "g8f6", "f3g1")
+
+	assert.Equal(t, 2, chessSession.Repetitions())
+	assert.ErrorIs(t, chessSession.ClaimDraw(board.Black, session.ThreefoldRepetition), session.ErrDrawClaimRejected)
+
+	playMoves(t, &chessSession, "f6g8")
+	assert.Equal(t, 3, chessSession.Repetitions())
+	assert.ErrorIs(t, chessSession.ClaimDraw(board.Black, session.ThreefoldRepetition), session.ErrNotYourTurn)
+	assert.ErrorIs(t, chessSession.ClaimDraw(board.White, session.Resignation), session.ErrInvalidDrawClaim)
+	assert.NoError(t, chessSession.ClaimDraw(board.White, session.ThreefoldRepetition))
+
+	assert.Equal(t, session.Draw, chessSession.Result())
+	assert.Equal(t, session.ThreefoldRepetition, chessSession.Termination())
+	assert.Equal(t, session.Action{
+		Type:        session.ClaimDrawAction,
+		Side:        board.White,
+		Ply:         8,
+		Termination: session.ThreefoldRepetition,
+	}, chessSession.ActionHistory[0])
+}
+
+func TestSession_ClaimFiftyMoveRule(t *testing.T) {
+	chessBoard := makeKingsBoard()
+	setFigure(&chessBoard, board.Rook, board.White, 3, 3)
+	chessBoard.SetHalfmoveClock(99)
+	chessSession, err := session.MakeSession(&chessBoard)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, chessSession.ClaimDraw(board.White, session.FiftyMoveRule), session.ErrDrawClaimRejected)
+	playMoves(t, &chessSession, "d4d5")
+	assert.NoError(t, chessSession.ClaimDraw(board.Black, session.FiftyMoveRule))
+
+	assert.Equal(t, session.Draw, chessSession.Result())
+	assert.Equal(t, session.FiftyMoveRule, chessSession.Termination())
+}
+
+func TestSession_Checkmate(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "f2f3", "e7e5", "g2g4", "d8h4")
+
+	assert.Equal(t, session.BlackWon, chessSession.Result())
+	assert.Equal(t, session.Checkmate, chessSession.Termination())
+	assert.Empty(t, chessSession.ActionHistory)
+}
+
+func TestSession_Stalemate(t *testing.T) {
+	chessBoard := board.MakeBoard()
+	setFigure(&chessBoard, board.King, board.Black, 7, 7)
+	setFigure(&chessBoard, board.King, board.White, 5, 6)
+	setFigure(&chessBoard, board.Queen, board.White, 6, 0)
+	chessSession, err := session.MakeSession(&chessBoard)
+	assert.NoError(t, err)
+
+	playMoves(t, &chessSession, "g1g6")
+
+	assert.Equal(t, session.Draw, chessSession.Result())
+	assert.Equal(t, session.Stalemate, chessSession.Termination())
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"chess/board"
+	"chess/search"
+	"chess/session"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func searchFEN(t *testing.T, fen string, limits search.Limits) (search.Result, error) {
+	position, err := board.ParseFEN(fen)
+	assert.NoError(t, err, fen)
+	return search.MakeSearcher(nil).Search(context.Background(), position, limits)
+}
+
+func TestSearch_MateInOne(t *testing.T) {
+	result, err := searchFEN(t, "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", search.Limits{Depth: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("a1a8"), result.Move)
+	assert.Equal(t, search.MateScore-1, result.Score)
+	assert.Equal(t, 1, search.MateIn(result.Score))
+	assert.Equal(t, []session.MoveRequest{moveRequest("a1a8")}, result.PV)
+}
+
+func TestSearch_MateInTwo(t *testing.T) {
+	result, err := searchFEN(t, "7k/8/5K2/8/8/8/8/R7 w - - 0 1", search.Limits{Depth: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, search.MateIn(result.Score))
+	assert.Len(t, result.PV, 3)
+}
+
+func TestSearch_GetsMated(t *testing.T) {
+	result, err := searchFEN(t, "k7/8/1K6/8/8/8/8/7R b - - 0 1", search.Limits{Depth: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, -1, search.MateIn(result.Score))
+}
+
+func TestSearch_WinsHangingQueen(t *testing.T) {
+	result, err := searchFEN(t, "4k3/8/8/3q4/8/8/8/3RK3 w - - 0 1", search.Limits{Depth: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("d1d5"), result.Move)
+	assert.Equal(t, 500, result.Score)
+}
+
+func TestSearch_PromotesPawn(t *testing.T) {
+	result, err := searchFEN(t, "8/4P1k1/8/8/8/8/8/4K3 w - - 0 1", search.Limits{Depth: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, moveRequest("e7e8q"), result.Move)
+}
+
+func TestSearch_NoLegalMoves(t *testing.T) {
+	result, err := searchFEN(t, "k7/8/1QK5/8/8/8/8/8 b - - 0 1", search.Limits{Depth: 3})
+	assert.ErrorIs(t, err, search.ErrNoLegalMoves)
+	assert.Equal(t, 0, result.Score)
+
+	result, err = searchFEN(t, "R5k1/5ppp/8/8/8/8/8/6K1 b - - 0 1", search.Limits{Depth: 3})
+	assert.ErrorIs(t, err, search.ErrNoLegalMoves)
+	assert.Equal(t, -search.MateScore, result.Score)
+}
+
+func TestSearch_PVIsPlayable(t *testing.T) {
+	position, err := board.ParseFEN("r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3")
+	assert.NoError(t, err)
+	result, err := search.MakeSearcher(nil).Search(context.Background(), position, search.Limits{Depth: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3", position.FEN())
+
+	assert.Equal(t, 3, result.Depth)
+	assert.Len(t, result.PV, 3)
+	assert.Equal(t, result.Move, result.PV[0])
+	chessSession := session.MakeUncheckedSession(position)
+	for _, request := range result.PV {
+		assert.NoError(t, chessSession.Move(request))
+	}
+}
+
+func TestSearch_NodeLimit(t *testing.T) {
+	result, err := searchFEN(t, board.DefaultFEN, search.Limits{Nodes: 5000})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, result.Nodes, 5000)
+	assert.GreaterOrEqual(t, result.Depth, 1)
+	assert.NotEqual(t, session.MoveRequest{}, result.Move)
+}
+
+func TestSearch_TimeLimit(t *testing.T) {
+	started := time.Now()
+	result, err := searchFEN(t, board.DefaultFEN, search.Limits{Time: 50 * time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(started), time.Second)
+	assert.GreaterOrEqual(t, result.Depth, 1)
+}
+
+func TestSearch_CancelledContextCompletesFirstIteration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := search.MakeSearcher(nil).Search(ctx, board.InitDefaultBoard(), search.Limits{Depth: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Depth)
+	assert.Len(t, result.PV, 1)
+}
+
+func TestSearch_OrdersKingCaptureBeforeQuietMoves(t *testing.T) {
+	position, err := board.ParseFEN("k7/8/8/8/8/8/4p3/4K2R w - - 0 1")
+	assert.NoError(t, err)
+	var first *board.Board
+	evaluator := func(position *board.Board) int {
+		if first == nil {
+			copied := position.Copy()
+			first = &copied
+		}
+		return search.MaterialEvaluator(position)
+	}
+
+	_, err = search.MakeSearcher(evaluator).Search(context.Background(), position, search.Limits{Depth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, board.Figure{FigureType: board.King, FigureSide: board.White, Moved: true}, first.GetField(cords("e2")).Figure)
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func seeOf(t *testing.T, fen string, move string) int {
+	chessBoard, err := board.ParseFEN(fen)
+	assert.NoError(t, err, fen)
+	return chessBoard.SEE(boardMove(t, chessBoard, move))
+}
+
+func TestSEE_UndefendedPawn(t *testing.T) {
+	assert.Equal(t, 1, seeOf(t, "1k1r4/1pp4p/p7/4p3/8/P5P1/1PP4P/2K1R3 w - - 0 1", "e1e5"))
+}
+
+func TestSEE_LosingExchangeWithXRays(t *testing.T) {
+	fen := "1k1r3q/1ppn3p/p4b2/4p3/8/P2N2P1/1PP1R1BP/2K1Q3 w - - 0 1"
+
+	assert.Equal(t, -2, seeOf(t, fen, "d3e5"))
+}
+
+func TestSEE_DoubledRooks(t *testing.T) {
+	assert.Equal(t, 1, seeOf(t, "3rk3/8/8/3p4/8/8/3R4/3RK3 w - - 0 1", "d2d5"))
+	assert.Equal(t, -4, seeOf(t, "3rk3/8/8/3p4/8/8/3R4/4K3 w - - 0 1", "d2d5"))
+}
+
+func TestSEE_QuietMove(t *testing.T) {
+	assert.Equal(t, 0, seeOf(t, "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a5"))
+	assert.Equal(t, -3, seeOf(t, "4k3/8/8/2p5/8/5N2/8/4K3 w - - 0 1", "f3d4"))
+}
+
+func TestSEE_KingRecapture(t *testing.T) {
+	assert.Equal(t, 1, seeOf(t, "8/8/3k4/3p4/8/8/3R4/3RK3 w - - 0 1", "d2d5"))
+	assert.Equal(t, -4, seeOf(t, "8/8/3k4/3p4/8/8/3R4/4K3 w - - 0 1", "d2d5"))
+}
+
+func TestSEE_Promotion(t *testing.T) {
+	assert.Equal(t, 13, seeOf(t, "3r3k/2P5/8/8/8/8/8/4K3 w - - 0 1", "c7d8q"))
+	assert.Equal(t, 8, seeOf(t, "7k/2P5/8/8/8/8/8/4K3 w - - 0 1", "c7c8q"))
+	assert.Equal(t, 5, seeOf(t, "3r3k/2P5/4n3/8/8/8/8/3QK3 w - - 0 1", "d1d8"))
+}
+
+func TestSEE_EnPassant(t *testing.T) {
+	chessBoard, err := board.ParseFEN("4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1")
+	assert.NoError(t, err)
+	move := board.MakeMove(chessBoard.GetField(cords("e5")), chessBoard.GetField(cords("d6")), board.EmptyType)
+
+	assert.Equal(t, 1, chessBoard.SEE(move))
+}
+
+func TestSEEAtLeast_AgreesWithSEE(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	generator := board.MakeStagedMoveGenerator()
+	for game := 0; game < 8; game++ {
+		chessBoard := board.InitDefaultBoard()
+		for ply := 0; ply < 80; ply++ {
+			moves := boxedMoves(generator.LegalMoves(chessBoard))
+			if len(moves) == 0 {
+				break
+			}
+			for _, move := range moves {
+				see := chessBoard.SEE(move)
+				for threshold := -10; threshold <= 14; threshold++ {
+					assert.Equal(t, see >= threshold, chessBoard.SEEAtLeast(move, threshold), "%s %s %d", chessBoard.FEN(), move, threshold)
+				}
+			}
+			chessBoard.MakeMove(moves[random.Intn(len(moves))])
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"chess/board"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func cords(notation string) board.Cords {
+	return board.Cords{Col: int(notation[0] - 'a'), Row: int(notation[1] - '1')}
+}
+
+func playMoves(t *testing.T, chessSession *session.Session, moves ...string) {
+	for _, move := range moves {
+		assert.NoError(t, chessSession.Move(moveRequest(move)), move)
+	}
+}
+
+func TestSessionMove_CastlingAfterDevelopment(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6", "e1g1")
+
+	assert.Equal(t, board.King, chessSession.ActualBoard.GetField(cords("g1")).Figure.FigureType)
+	assert.Equal(t, board.Rook, chessSession.ActualBoard.GetField(cords("f1")).Figure.FigureType)
+	assert.Len(t, chessSession.BoardHistory, 7)
+}
+
+func TestSessionMove_DiagonalOpenedDuringGame(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	playMoves(t, &chessSession, "e2e4", "e7e5", "d1h5", "b8c6", "f1c4", "g8f6", "h5f7")
+
+	assert.Equal(t, board.Queen, chessSession.ActualBoard.GetField(cords("f7")).Figure.FigureType)
+	assert.True(t, chessSession.ActualBoard.IsInCheck(board.Black))
+}
+
+func TestSessionMove_PathClosedDuringGame(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "d1h5", "g7g6")
+
+	err := chessSession.Move(session.MoveRequest{DepartureCords: cords("h5"), DestinationCords: cords("h8")})
+
+	assertValidationError(t, err, "LinePathValidator", board.PathBlockedCode)
+}
+
+func TestSessionMove_PinAppearsDuringGame(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "d2d4", "f8b4", "b1c3", "g8f6")
+
+	err := chessSession.Move(session.MoveRequest{DepartureCords: cords("c3"), DestinationCords: cords("d5")})
+	assertValidationError(t, err, "KingIsNotAttackedAfterMoveValidator", board.KingAttackedCode)
+
+	playMoves(t, &chessSession, "c1d2", "b8c6")
+	err = chessSession.Move(session.MoveRequest{DepartureCords: cords("c3"), DestinationCords: cords("d5")})
+	assert.NoError(t, err)
+}
+
+func TestSessionMove_EnPassantSeveralPliesIn(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "a7a6", "e4e5", "d7d5", "e5d6")
+
+	assert.False(t, chessSession.ActualBoard.GetField(cords("d5")).Filled)
+	assert.Equal(t, board.Pawn, chessSession.ActualBoard.GetField(cords("d6")).Figure.FigureType)
+}
+
+func TestSessionMove_EnPassantExpires(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "a7a6", "e4e5", "d7d5", "h2h3", "h7h6")
+
+	err := chessSession.Move(session.MoveRequest{DepartureCords: cords("e5"), DestinationCords: cords("d6")})
+
+	assertValidationError(t, err, "PawnMoveValidator", board.IllegalFigureMoveCode)
+}
+
+func TestSessionMove_KingMovesAfterCastlingRightLost(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "e1e2", "e8e7", "e2e1", "e7e8")
+
+	err := chessSession.Move(session.MoveRequest{DepartureCords: cords("g1"), DestinationCords: cords("f3")})
+	assert.NoError(t, err)
+	playMoves(t, &chessSession, "g8f6", "f1c4", "f8c5")
+	err = chessSession.Move(session.MoveRequest{DepartureCords: cords("e1"), DestinationCords: cords("g1")})
+
+	assertValidationError(t, err, "CastlingMoveValidator", board.CastlingNotAllowedCode)
+}
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"chess/board"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// boxedMoves converts generated moves to moves taken by the rest of the board API
+func boxedMoves(moves []board.GeneratedMove) []board.Move {
+	boxed := make([]board.Move, 0, len(moves))
+	for _, move := range moves {
+		boxed = append(boxed, move.Move())
+	}
+	return boxed
+}
+
+func stagedPerft(generator *board.StagedMoveGenerator, position *board.Board, depth int) int {
+	moves := boxedMoves(generator.LegalMoves(position))
+	if depth == 1 {
+		return len(moves)
+	}
+	nodes := 0
+	for _, move := range moves {
+		position.MakeMove(move)
+		nodes += stagedPerft(generator, position, depth-1)
+		position.UnmakeMove()
+	}
+	return nodes
+}
+
+func TestStagedGenerator_Perft(t *testing.T) {
+	generator := board.MakeStagedMoveGenerator()
+	for _, reference := range perftPositions {
+		chessBoard, err := board.ParseFEN(reference.fen)
+		assert.NoError(t, err, reference.name)
+		for depth, nodes := range reference.nodes {
+			assert.Equal(t, nodes, stagedPerft(generator, chessBoard, depth+1), "%s depth %d", reference.name, depth+1)
+		}
+	}
+}
+
+func TestStagedGenerator_AgreesWithValidators(t *testing.T) {
+	random := rand.New(rand.NewSource(11))
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	staged := board.MakeStagedMoveGenerator()
+	for game := 0; game < 10; game++ {
+		chessBoard := board.InitDefaultBoard()
+		for ply := 0; ply < 100; ply++ {
+			moves := generator.LegalMoves(*chessBoard)
+			assert.Equal(t, sortedBoardMoves(moves), sortedBoardMoves(boxedMoves(staged.LegalMoves(chessBoard))), "game %d ply %d", game, ply)
+			if len(moves) == 0 {
+				break
+			}
+			chessBoard.MakeMove(moves[random.Intn(len(moves))])
+		}
+	}
+}
+
+func TestStagedGenerator_Stages(t *testing.T) {
+	chessBoard, err := board.ParseFEN("4k3/1P6/8/3p4/4P3/8/8/R3K2R w KQ - 0 1")
+	assert.NoError(t, err)
+	generator := board.MakeStagedMoveGenerator()
+	generator.Reset(chessBoard)
+
+	stage, captures, ok := generator.NextStage()
+	assert.True(t, ok)
+	assert.Equal(t, board.CapturesStage, stage)
+	assert.Equal(t, []string{"b7b8b", "b7b8n", "b7b8q", "b7b8r", "e4d5"}, sortedBoardMoves(boxedMoves(captures)))
+
+	stage, quiets, ok := generator.NextStage()
+	assert.True(t, ok)
+	assert.Equal(t, board.QuietsStage, stage)
+	assert.Contains(t, sortedBoardMoves(boxedMoves(quiets)), "e1g1")
+	assert.Contains(t, sortedBoardMoves(boxedMoves(quiets)), "e1c1")
+	assert.Contains(t, sortedBoardMoves(boxedMoves(quiets)), "e4e5")
+	assert.NotContains(t, sortedBoardMoves(boxedMoves(quiets)), "e4d5")
+
+	stage, _, ok = generator.NextStage()
+	assert.False(t, ok)
+	assert.Equal(t, board.DoneStage, stage)
+}
+
+func TestStagedGenerator_Evasions(t *testing.T) {
+	chessBoard, err := board.ParseFEN("4k3/8/8/8/1b6/8/2P5/R1N1K2R w KQ - 0 1")
+	assert.NoError(t, err)
+	generator := board.MakeStagedMoveGenerator()
+	generator.Reset(chessBoard)
+
+	stage, evasions, ok := generator.NextStage()
+
+	assert.True(t, ok)
+	assert.Equal(t, board.EvasionsStage, stage)
+	assert.Equal(t, []string{"c2c3", "e1d1", "e1e2", "e1f1", "e1f2"}, sortedBoardMoves(boxedMoves(evasions)))
+	_, _, ok = generator.NextStage()
+	assert.False(t, ok)
+}
+
+func TestStagedGenerator_PinnedFigure(t *testing.T) {
+	chessBoard, err := board.ParseFEN("4k3/8/8/8/4r3/8/4R3/4K3 w - - 0 1")
+	assert.NoError(t, err)
+	generator := board.MakeStagedMoveGenerator()
+
+	moves := sortedBoardMoves(boxedMoves(generator.LegalMoves(chessBoard)))
+
+	assert.Equal(t, []string{"e1d1", "e1d2", "e1f1", "e1f2", "e2e3", "e2e4"}, moves)
+}
+
+func TestStagedGenerator_PawnOnLastRow(t *testing.T) {
+	for _, fen := range []string{"P3k3/8/8/8/8/8/8/4K3 w - - 0 1", "4k3/8/8/8/8/8/8/p3K3 b - - 0 1"} {
+		chessBoard, err := board.ParseFEN(fen)
+		assert.NoError(t, err, fen)
+		expected := board.MakeMoveGenerator(board.InitValidators()).LegalMoves(*chessBoard)
+
+		moves := board.MakeStagedMoveGenerator().LegalMoves(chessBoard)
+
+		assert.Equal(t, sortedBoardMoves(expected), sortedBoardMoves(boxedMoves(moves)), fen)
+	}
+}
+
+func TestStagedGenerator_DoesNotAllocate(t *testing.T) {
+	generator := board.MakeStagedMoveGenerator()
+	for _, reference := range perftPositions {
+		chessBoard, err := board.ParseFEN(reference.fen)
+		assert.NoError(t, err, reference.name)
+		assert.Zero(t, testing.AllocsPerRun(10, func() { generator.LegalMoves(chessBoard) }), reference.name)
+	}
+}
+
+func BenchmarkLegalMoves_Staged(b *testing.B) {
+	chessBoard := middlegameBoard(b)
+	generator := board.MakeStagedMoveGenerator()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		generator.LegalMoves(chessBoard)
+	}
+}
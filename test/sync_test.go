@@ -0,0 +1,190 @@
+package test
+
+import (
+	"chess/board"
+	"chess/clock"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// moveRequest parses move in coordinate notation, e.g. e2e4 or e7e8q
+func moveRequest(move string) session.MoveRequest {
+	request := session.MoveRequest{DepartureCords: cords(move[0:2]), DestinationCords: cords(move[2:4])}
+	if len(move) == 5 {
+		request.PromoteToType = map[byte]board.FigureType{
+			'q': board.Queen, 'r': board.Rook, 'b': board.Bishop, 'n': board.Knight,
+		}[move[4]]
+	}
+	return request
+}
+
+func TestSyncSession_PlayersInSeparateGoroutines(t *testing.T) {
+	syncSession := session.MakeSyncSession(session.MakeDefaultSession())
+	moves := map[board.FigureSide][]string{
+		board.White: {"e2e4", "g1f3", "f1b5", "b5c6", "e1g1"},
+		board.Black: {"e7e5", "b8c6", "a7a6", "d7c6"},
+	}
+
+	var wait sync.WaitGroup
+	for side, sideMoves := range moves {
+		wait.Add(1)
+		go func(side board.FigureSide, sideMoves []string) {
+			defer wait.Done()
+			for len(sideMoves) > 0 {
+				snapshot := syncSession.Snapshot()
+				if snapshot.Board.SideToMove() != side {
+					runtime.Gosched()
+					continue
+				}
+				assert.NoError(t, syncSession.MoveAt(snapshot.Sequence, moveRequest(sideMoves[0])))
+				sideMoves = sideMoves[1:]
+			}
+		}(side, sideMoves)
+	}
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for syncSession.Sequence() < 9 {
+			snapshot := syncSession.Snapshot()
+			assert.Len(t, snapshot.MoveHistory, snapshot.Sequence)
+		}
+	}()
+	wait.Wait()
+
+	snapshot := syncSession.Snapshot()
+	assert.Equal(t, 9, snapshot.Sequence)
+	syncSession.View(func(chessSession *session.Session) {
+		assert.Equal(t, "1. e4 e5 2. Nf3 Nc6 3. Bb5 a6 4. Bxc6 dxc6 5. O-O", chessSession.MoveText())
+	})
+}
+
+func TestSyncSession_OnlyOneConcurrentMoveWins(t *testing.T) {
+	syncSession := session.MakeSyncSession(session.MakeDefaultSession())
+	moves := []string{"e2e4", "d2d4", "c2c4", "g1f3", "b1c3", "g2g3", "b2b3", "f2f4"}
+
+	var wait sync.WaitGroup
+	results := make([]error, len(moves))
+	for i, move := range moves {
+		wait.Add(1)
+		go func(i int, move string) {
+			defer wait.Done()
+			results[i] = syncSession.MoveAt(0, moveRequest(move))
+		}(i, move)
+	}
+	wait.Wait()
+
+	applied := 0
+	for _, err := range results {
+		if err == nil {
+			applied++
+		} else {
+			assert.ErrorIs(t, err, session.ErrStaleSequence)
+		}
+	}
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, 1, syncSession.Sequence())
+	snapshot := syncSession.Snapshot()
+	assert.Equal(t, board.Black, snapshot.Board.SideToMove())
+}
+
+func TestSyncSession_SnapshotIsIndependent(t *testing.T) {
+	syncSession := session.MakeSyncSession(session.MakeDefaultSession())
+	assert.NoError(t, syncSession.Move(moveRequest("e2e4")))
+	snapshot := syncSession.Snapshot()
+
+	assert.NoError(t, syncSession.Move(moveRequest("d7d5")))
+	assert.NoError(t, syncSession.Move(moveRequest("e4d5")))
+
+	assert.Equal(t, 1, snapshot.Sequence)
+	assert.Len(t, snapshot.MoveHistory, 1)
+	assert.True(t, snapshot.Board.GetField(cords("e4")).Filled)
+	assert.True(t, snapshot.Board.GetField(cords("d7")).Filled)
+	assert.NotNil(t, snapshot.Board.EnPassantCords())
+}
+
+func TestSyncSession_FailedChangeKeepsSequence(t *testing.T) {
+	syncSession := session.MakeSyncSession(session.MakeDefaultSession())
+
+	assert.Error(t, syncSession.Move(moveRequest("e2e5")))
+	assert.ErrorIs(t, syncSession.AcceptDraw(board.Black), session.ErrNoDrawOffer)
+	assert.Equal(t, 0, syncSession.Sequence())
+
+	assert.NoError(t, syncSession.OfferDraw(board.White))
+	assert.NoError(t, syncSession.AcceptDraw(board.Black))
+	assert.Equal(t, 2, syncSession.Sequence())
+	assert.Equal(t, session.Draw, syncSession.Snapshot().Result)
+	assert.ErrorIs(t, syncSession.MoveAt(1, moveRequest("e2e4")), session.ErrStaleSequence)
+}
+
+func TestSyncSession_FlagFallingOnMoveBumpsSequence(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessSession := session.MakeDefaultSession()
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+	syncSession := session.MakeSyncSession(chessSession)
+
+	timeSource.advance(2 * time.Minute)
+	assert.ErrorIs(t, syncSession.MoveAt(0, moveRequest("e2e4")), session.ErrGameOver)
+
+	snapshot := syncSession.Snapshot()
+	assert.Equal(t, 1, snapshot.Sequence)
+	assert.Equal(t, session.BlackWon, snapshot.Result)
+	assert.True(t, syncSession.CheckFlag())
+	assert.Equal(t, 1, syncSession.Sequence())
+}
+
+func TestSyncSession_UndoRedoAndWithdrawDraw(t *testing.T) {
+	syncSession := session.MakeSyncSession(session.MakeDefaultSession())
+
+	assert.NoError(t, syncSession.Move(moveRequest("e2e4")))
+	assert.NoError(t, syncSession.Undo())
+	assert.NoError(t, syncSession.Redo())
+	assert.NoError(t, syncSession.OfferDraw(board.Black))
+	assert.NoError(t, syncSession.WithdrawDraw(board.Black))
+	assert.ErrorIs(t, syncSession.Redo(), session.ErrNothingToRedo)
+
+	snapshot := syncSession.Snapshot()
+	assert.Equal(t, 5, snapshot.Sequence)
+	assert.Len(t, snapshot.MoveHistory, 1)
+	assert.Equal(t, board.EmptySide, snapshot.DrawOffer)
+}
+
+func TestSyncSession_ConcurrentSubscriptions(t *testing.T) {
+	syncSession := session.MakeSyncSession(session.MakeDefaultSession())
+	moves := []string{"g1f3", "g8f6", "f3g1", "f6g8"}
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for i := 0; i < 40; i++ {
+			assert.NoError(t, syncSession.Move(moveRequest(moves[i%len(moves)])))
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for j := 0; j < 20; j++ {
+				unsubscribe := syncSession.Subscribe(func(session.Event) {})
+				events, closeEvents := syncSession.Events(0)
+				select {
+				case <-events:
+				default:
+				}
+				closeEvents()
+				unsubscribe()
+			}
+		}()
+	}
+	wait.Wait()
+
+	events, closeEvents := syncSession.Events(1)
+	assert.NoError(t, syncSession.Move(moveRequest("e2e4")))
+	assert.Equal(t, session.MoveAppliedEvent, (<-events).Type)
+	closeEvents()
+	assert.Equal(t, 41, syncSession.Sequence())
+}
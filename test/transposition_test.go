@@ -0,0 +1,190 @@
+package test
+
+import (
+	"chess/board"
+	"chess/search"
+	"chess/session"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func boardMove(t *testing.T, position *board.Board, move string) board.Move {
+	request := moveRequest(move)
+	departure := position.GetField(request.DepartureCords)
+	destination := position.GetField(request.DestinationCords)
+	assert.True(t, departure.Filled, move)
+	return board.MakeMove(departure, destination, request.PromoteToType)
+}
+
+func TestTranspositionTable_StoreAndProbe(t *testing.T) {
+	table := search.MakeTranspositionTable(1)
+	position := board.InitDefaultBoard()
+	table.Store(position.Hash(), 0, 5, 37, search.LowerBound, boardMove(t, position, "e2e4"))
+
+	entry, found := table.Probe(position.Hash(), 0)
+	assert.True(t, found)
+	assert.Equal(t, search.TTEntry{Move: moveRequest("e2e4"), HasMove: true, Score: 37, Depth: 5, Bound: search.LowerBound}, entry)
+	_, found = table.Probe(position.Hash()^1, 0)
+	assert.False(t, found)
+
+	table.Store(position.Hash(), 0, 6, -12, search.UpperBound, nil)
+	entry, _ = table.Probe(position.Hash(), 0)
+	assert.Equal(t, search.TTEntry{Move: moveRequest("e2e4"), HasMove: true, Score: -12, Depth: 6, Bound: search.UpperBound}, entry)
+
+	table.Clear()
+	_, found = table.Probe(position.Hash(), 0)
+	assert.False(t, found)
+}
+
+func TestTranspositionTable_PromotionMove(t *testing.T) {
+	table := search.MakeTranspositionTable(1)
+	position, err := board.ParseFEN("8/4P1k1/8/8/8/8/8/4K3 w - - 0 1")
+	assert.NoError(t, err)
+	table.Store(position.Hash(), 0, 1, 800, search.ExactBound, boardMove(t, position, "e7e8n"))
+
+	entry, _ := table.Probe(position.Hash(), 0)
+	assert.Equal(t, moveRequest("e7e8n"), entry.Move)
+}
+
+func TestTranspositionTable_MateScoresAdjustedByPly(t *testing.T) {
+	table := search.MakeTranspositionTable(1)
+	table.Store(1, 3, 4, search.MateScore-5, search.ExactBound, nil)
+	table.Store(2, 3, 4, -search.MateScore+5, search.ExactBound, nil)
+	table.Store(3, 3, 4, 250, search.ExactBound, nil)
+
+	entry, _ := table.Probe(1, 1)
+	assert.Equal(t, search.MateScore-3, entry.Score)
+	assert.False(t, entry.HasMove)
+	entry, _ = table.Probe(2, 1)
+	assert.Equal(t, -search.MateScore+3, entry.Score)
+	entry, _ = table.Probe(3, 1)
+	assert.Equal(t, 250, entry.Score)
+}
+
+func TestTranspositionTable_Replacement(t *testing.T) {
+	// the smallest table has a single bucket of four entries
+	table := search.MakeTranspositionTable(0)
+	for hash, depth := range map[uint64]int{1: 3, 2: 1, 3: 4, 4: 2} {
+		table.Store(hash, 0, depth, 0, search.ExactBound, nil)
+	}
+	table.Store(5, 0, 2, 0, search.ExactBound, nil)
+	_, found := table.Probe(2, 0)
+	assert.False(t, found, "the shallowest entry is replaced")
+	_, found = table.Probe(5, 0)
+	assert.True(t, found)
+
+	table.NewSearch()
+	table.Store(6, 0, 1, 0, search.ExactBound, nil)
+	table.Store(7, 0, 1, 0, search.ExactBound, nil)
+	for _, hash := range []uint64{6, 7, 3} {
+		_, found = table.Probe(hash, 0)
+		assert.True(t, found, "hash %d", hash)
+	}
+}
+
+func TestTranspositionTable_Hashfull(t *testing.T) {
+	table := search.MakeTranspositionTable(0)
+	assert.Equal(t, 0, table.Hashfull())
+
+	table.Store(1, 0, 1, 0, search.ExactBound, nil)
+	assert.Equal(t, 250, table.Hashfull())
+	table.Store(2, 0, 1, 0, search.ExactBound, nil)
+	assert.Equal(t, 500, table.Hashfull())
+
+	table.NewSearch()
+	assert.Equal(t, 0, table.Hashfull())
+	table.Store(1, 0, 1, 0, search.ExactBound, nil)
+	assert.Equal(t, 250, table.Hashfull())
+}
+
+func TestTranspositionTable_NegativeSize(t *testing.T) {
+	table := search.MakeTranspositionTable(-1)
+
+	table.Store(1, 0, 1, 0, search.ExactBound, nil)
+	assert.Equal(t, 250, table.Hashfull(), "a negative size makes the smallest table")
+	_, found := table.Probe(1, 0)
+	assert.True(t, found)
+}
+
+func TestTranspositionTable_ConcurrentAccess(t *testing.T) {
+	table := search.MakeTranspositionTable(0)
+	var wait sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wait.Add(1)
+		go func(worker int) {
+			defer wait.Done()
+			for i := 0; i < 2000; i++ {
+				hash := uint64(i%16+1) * 0x9E3779B97F4A7C15
+				table.Store(hash, 0, i%16+1, i%16*10, search.ExactBound, nil)
+				if entry, found := table.Probe(hash, 0); found {
+					assert.Equal(t, i%16*10, entry.Score)
+					assert.Equal(t, i%16+1, entry.Depth)
+				}
+			}
+		}(worker)
+	}
+	wait.Wait()
+}
+
+func TestSearch_WithTranspositionTable(t *testing.T) {
+	for _, fen := range []string{
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"4k3/8/8/3q4/8/8/8/3RK3 w - - 0 1",
+		"7k/8/5K2/8/8/8/8/R7 w - - 0 1",
+	} {
+		position, err := board.ParseFEN(fen)
+		assert.NoError(t, err)
+		plain, err := search.MakeSearcher(nil).Search(context.Background(), position, search.Limits{Depth: 4})
+		assert.NoError(t, err)
+
+		searcher := search.MakeSearcher(nil)
+		table := search.MakeTranspositionTable(1)
+		searcher.UseTranspositionTable(table)
+		table.NewSearch()
+		hashed, err := searcher.Search(context.Background(), position, search.Limits{Depth: 4})
+		assert.NoError(t, err)
+
+		assert.Equal(t, plain.Score, hashed.Score, fen)
+		assert.LessOrEqual(t, hashed.Nodes, plain.Nodes, fen)
+		assert.Equal(t, 0, plain.Hashfull)
+	}
+}
+
+func TestSearch_ReportsHashfull(t *testing.T) {
+	position, err := board.ParseFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	assert.NoError(t, err)
+	searcher := search.MakeSearcher(nil)
+	table := search.MakeTranspositionTable(1)
+	searcher.UseTranspositionTable(table)
+	table.NewSearch()
+	result, err := searcher.Search(context.Background(), position, search.Limits{Depth: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, table.Hashfull(), result.Hashfull)
+	assert.Greater(t, result.Hashfull, 0)
+	assert.Less(t, result.Hashfull, 1000)
+}
+
+func TestSearch_SharedTranspositionTable(t *testing.T) {
+	table := search.MakeTranspositionTable(1)
+	table.NewSearch()
+	results := make([]session.MoveRequest, 2)
+	var wait sync.WaitGroup
+	for i := range results {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			searcher := search.MakeSearcher(nil)
+			searcher.UseTranspositionTable(table)
+			result, err := searcher.Search(context.Background(), board.InitDefaultBoard(), search.Limits{Depth: 3})
+			assert.NoError(t, err)
+			results[i] = result.Move
+		}(i)
+	}
+	wait.Wait()
+	for _, move := range results {
+		assert.NotEqual(t, session.MoveRequest{}, move)
+	}
+}
@@ -0,0 +1,166 @@
+package test
+
+import (
+	"chess/board"
+	"chess/clock"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSessionUndo_RestoresPosition(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "g1f3")
+	beforeLastMove := chessSession.BoardHistory[2]
+
+	assert.NoError(t, chessSession.Undo())
+
+	assert.True(t, chessSession.ActualBoard.SamePosition(&beforeLastMove))
+	assert.Equal(t, board.White, chessSession.SideToMove())
+	assert.Equal(t, 0, chessSession.ActualBoard.HalfmoveClock())
+	assert.Equal(t, 2, chessSession.ActualBoard.FullmoveNumber())
+	assert.Len(t, chessSession.BoardHistory, 2)
+	assert.False(t, chessSession.ActualBoard.GetField(cords("f3")).Filled)
+}
+
+func TestSessionUndo_RestoresCastlingRights(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6", "e1e2")
+	assert.False(t, chessSession.ActualBoard.CastlingRights().WhiteShort)
+
+	assert.NoError(t, chessSession.Undo())
+	assert.True(t, chessSession.ActualBoard.CastlingRights().WhiteShort)
+	playMoves(t, &chessSession, "e1g1")
+}
+
+func TestSessionUndo_NothingToUndo(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+
+	assert.ErrorIs(t, chessSession.Undo(), session.ErrNothingToUndo)
+	assert.ErrorIs(t, chessSession.Redo(), session.ErrNothingToRedo)
+}
+
+func TestSessionRedo(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5")
+	afterMoves := *chessSession.ActualBoard
+
+	assert.NoError(t, chessSession.Undo())
+	assert.NoError(t, chessSession.Undo())
+	assert.NoError(t, chessSession.Redo())
+	assert.NoError(t, chessSession.Redo())
+
+	assert.True(t, chessSession.ActualBoard.SamePosition(&afterMoves))
+	assert.Len(t, chessSession.BoardHistory, 2)
+	assert.ErrorIs(t, chessSession.Redo(), session.ErrNothingToRedo)
+}
+
+func TestSessionRedo_ClearedByMove(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4")
+	assert.NoError(t, chessSession.Undo())
+
+	playMoves(t, &chessSession, "d2d4")
+
+	assert.ErrorIs(t, chessSession.Redo(), session.ErrNothingToRedo)
+}
+
+func TestSessionUndo_Checkmate(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "f2f3", "e7e5", "g2g4", "d8h4")
+	assert.True(t, chessSession.IsOver())
+
+	assert.NoError(t, chessSession.Undo())
+	assert.False(t, chessSession.IsOver())
+	assert.Equal(t, session.Ongoing, chessSession.Result())
+
+	assert.NoError(t, chessSession.Redo())
+	assert.Equal(t, session.BlackWon, chessSession.Result())
+	assert.Equal(t, session.Checkmate, chessSession.Termination())
+}
+
+func TestSessionUndo_AfterResignation(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4")
+	assert.NoError(t, chessSession.Resign(board.Black))
+
+	assert.ErrorIs(t, chessSession.Undo(), session.ErrGameOver)
+}
+
+func TestSessionUndo_RestartsClockOfSideToMove(t *testing.T) {
+	timeSource := makeFakeTimeSource()
+	chessSession := session.MakeDefaultSession()
+	chessSession.AttachClock(makeClock(t, clock.SuddenDeath(time.Minute), timeSource))
+	timeSource.advance(10 * time.Second)
+	playMoves(t, &chessSession, "e2e4")
+	timeSource.advance(5 * time.Second)
+
+	assert.NoError(t, chessSession.Undo())
+	timeSource.advance(5 * time.Second)
+
+	assert.Equal(t, board.White, chessSession.Clock().Running())
+	assert.Equal(t, 45*time.Second, chessSession.Clock().Remaining(board.White))
+	assert.Equal(t, 55*time.Second, chessSession.Clock().Remaining(board.Black))
+}
+
+func TestSessionTakeback_OpponentAccepts(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "f1c4")
+
+	assert.NoError(t, chessSession.RequestTakeback(board.White))
+	assert.ErrorIs(t, chessSession.RequestTakeback(board.Black), session.ErrTakebackAlreadyPending)
+	assert.ErrorIs(t, chessSession.AcceptTakeback(board.White), session.ErrNoTakebackRequest)
+	assert.NoError(t, chessSession.AcceptTakeback(board.Black))
+
+	assert.Len(t, chessSession.BoardHistory, 2)
+	assert.Equal(t, board.White, chessSession.SideToMove())
+	assert.ErrorIs(t, chessSession.Redo(), session.ErrNothingToRedo)
+	assert.Equal(t, session.AcceptTakebackAction, chessSession.ActionHistory[1].Type)
+}
+
+func TestSessionTakeback_AfterOpponentReplied(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4", "e7e5", "f1c4", "b8c6")
+
+	assert.NoError(t, chessSession.RequestTakeback(board.White))
+	assert.NoError(t, chessSession.AcceptTakeback(board.Black))
+
+	assert.Len(t, chessSession.BoardHistory, 2)
+	assert.Equal(t, board.White, chessSession.SideToMove())
+	assert.True(t, chessSession.ActualBoard.GetField(cords("f1")).Filled)
+}
+
+func TestSessionTakeback_Declined(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4")
+
+	assert.ErrorIs(t, chessSession.RequestTakeback(board.Black), session.ErrNothingToUndo)
+	assert.NoError(t, chessSession.RequestTakeback(board.White))
+	assert.NoError(t, chessSession.DeclineTakeback(board.Black))
+
+	_, requested := chessSession.TakebackRequest()
+	assert.False(t, requested)
+	assert.Len(t, chessSession.BoardHistory, 1)
+}
+
+func TestSessionTakeback_ExpiresAfterMove(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "e2e4")
+	assert.NoError(t, chessSession.RequestTakeback(board.White))
+
+	playMoves(t, &chessSession, "e7e5")
+
+	assert.ErrorIs(t, chessSession.AcceptTakeback(board.Black), session.ErrNoTakebackRequest)
+}
+
+func TestSessionTakeback_Disabled(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	chessSession.SetTakebacksAllowed(false)
+	playMoves(t, &chessSession, "e2e4")
+
+	assert.False(t, chessSession.TakebacksAllowed())
+	assert.ErrorIs(t, chessSession.RequestTakeback(board.White), session.ErrTakebacksDisabled)
+	assert.ErrorIs(t, chessSession.Undo(), session.ErrTakebacksDisabled)
+	assert.ErrorIs(t, chessSession.Redo(), session.ErrTakebacksDisabled)
+}
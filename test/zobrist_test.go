@@ -0,0 +1,133 @@
+package test
+
+import (
+	"chess/board"
+	"chess/session"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestHash_IncrementalAgreesWithComputed(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	generator := board.MakeMoveGenerator(board.InitValidators())
+	for game := 0; game < 10; game++ {
+		chessBoard := board.InitDefaultBoard()
+		hashes := []uint64{chessBoard.Hash()}
+		assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+		for ply := 0; ply < 80; ply++ {
+			moves := generator.LegalMoves(*chessBoard)
+			if len(moves) == 0 {
+				break
+			}
+			move := moves[random.Intn(len(moves))]
+			copied := chessBoard.Move(move)
+			chessBoard.MakeMove(move)
+			assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash(), "game %d ply %d", game, ply)
+			assert.Equal(t, chessBoard.Hash(), copied.Hash(), "game %d ply %d", game, ply)
+			hashes = append(hashes, chessBoard.Hash())
+		}
+		for chessBoard.MadeMoves() > 0 {
+			chessBoard.UnmakeMove()
+			assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+			assert.Equal(t, hashes[chessBoard.MadeMoves()], chessBoard.Hash())
+		}
+	}
+}
+
+func TestHash_TranspositionsAreEqual(t *testing.T) {
+	first := session.MakeDefaultSession()
+	second := session.MakeDefaultSession()
+
+	playMoves(t, &first, "g1f3", "g8f6", "b1c3", "b8c6")
+	playMoves(t, &second, "b1c3", "b8c6", "g1f3", "g8f6")
+
+	assert.Equal(t, first.ActualBoard.Hash(), second.ActualBoard.Hash())
+	assert.NotEqual(t, board.InitDefaultBoard().Hash(), first.ActualBoard.Hash())
+}
+
+func TestHash_SideToMove(t *testing.T) {
+	chessSession := session.MakeDefaultSession()
+	playMoves(t, &chessSession, "g1f3", "g8f6", "f3g1", "f6g8")
+	blackToMove := board.InitDefaultBoard()
+	blackToMove.SetSideToMove(board.Black)
+
+	assert.Equal(t, board.InitDefaultBoard().Hash(), chessSession.ActualBoard.Hash())
+	assert.NotEqual(t, board.InitDefaultBoard().Hash(), blackToMove.Hash())
+}
+
+func TestHash_CastlingRights(t *testing.T) {
+	withRights := session.MakeDefaultSession()
+	withoutRights := session.MakeDefaultSession()
+
+	playMoves(t, &withRights, "e2e4", "e7e5", "g1f3", "g8f6", "f3g1", "f6g8")
+	playMoves(t, &withoutRights, "e2e4", "e7e5", "e1e2", "e8e7", "e2e1", "e7e8")
+
+	assert.False(t, withRights.ActualBoard.SamePosition(withoutRights.ActualBoard))
+	assert.NotEqual(t, withRights.ActualBoard.Hash(), withoutRights.ActualBoard.Hash())
+}
+
+func TestHash_EnPassantOnlyWhenCapturable(t *testing.T) {
+	capturable := session.MakeDefaultSession()
+	notCapturable := session.MakeDefaultSession()
+
+	playMoves(t, &capturable, "e2e4", "a7a6", "e4e5", "d7d5")
+	playMoves(t, &notCapturable, "e2e4", "a7a6", "e4e5", "h7h5")
+
+	withoutCapturableEnPassant := capturable.ActualBoard.Copy()
+	withoutCapturableEnPassant.SetEnPassantCords(nil)
+	assert.NotEqual(t, withoutCapturableEnPassant.Hash(), capturable.ActualBoard.Hash())
+	withoutEnPassant := notCapturable.ActualBoard.Copy()
+	withoutEnPassant.SetEnPassantCords(nil)
+	assert.Equal(t, withoutEnPassant.Hash(), notCapturable.ActualBoard.Hash())
+}
+
+func TestComputeHash_CastlingRights(t *testing.T) {
+	hashes := map[uint64]string{}
+	for _, rights := range []string{"-", "K", "Q", "k", "q", "KQ", "kq", "Kk", "KQkq"} {
+		chessBoard, err := board.ParseFEN("r3k2r/8/8/8/8/8/8/R3K2R w " + rights + " - 0 1")
+		assert.NoError(t, err, rights)
+		assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash(), rights)
+		assert.NotContains(t, hashes, chessBoard.Hash(), rights)
+		hashes[chessBoard.Hash()] = rights
+	}
+}
+
+func TestComputeHash_EnPassant(t *testing.T) {
+	for fen, capturable := range map[string]bool{
+		"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2": true,
+		"4k3/8/8/3p3P/8/8/8/4K3 w - d6 0 2": false,
+		"4k3/8/8/8/4Pp2/8/8/4K3 b - e3 0 1": true,
+		"4k3/8/8/8/P3P3/8/8/4K3 b - e3 0 1": false,
+	} {
+		chessBoard, err := board.ParseFEN(fen)
+		assert.NoError(t, err, fen)
+		assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash(), fen)
+		withoutEnPassant := chessBoard.Copy()
+		withoutEnPassant.SetEnPassantCords(nil)
+		assert.Equal(t, withoutEnPassant.ComputeHash(), withoutEnPassant.Hash(), fen)
+		assert.Equal(t, capturable, chessBoard.Hash() != withoutEnPassant.Hash(), fen)
+	}
+}
+
+func TestComputeHash_AfterSetters(t *testing.T) {
+	chessBoard, err := board.ParseFEN("r3k2r/8/8/3pP3/8/8/8/R3K2R w KQkq d6 0 2")
+	assert.NoError(t, err)
+
+	chessBoard.SetSideToMove(board.Black)
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetSideToMove(board.White)
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetField(board.Field{Cords: board.Cords{Col: 4, Row: 4}})
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetField(board.Field{Figure: board.Figure{FigureType: board.Pawn, FigureSide: board.White}, Cords: board.Cords{Col: 2, Row: 4}, Filled: true})
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetCastlingRights(board.CastlingRights{WhiteShort: true, BlackLong: true})
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetField(board.Field{Cords: board.Cords{Col: 7, Row: 0}})
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetEnPassantCords(nil)
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+	chessBoard.SetEnPassantCords(&board.Cords{Col: 3, Row: 5})
+	assert.Equal(t, chessBoard.ComputeHash(), chessBoard.Hash())
+}